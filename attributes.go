@@ -0,0 +1,46 @@
+package minio
+
+import (
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ObjectAttributes is the metadata of a file.
+type ObjectAttributes struct {
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentType        string
+	ETag               string
+	// LastModified is in the timezone of the application.
+	LastModified time.Time
+	// Metadata is the user metadata, x-amz-meta-* headers without the prefix.
+	Metadata     map[string]string
+	Size         int64
+	StorageClass string
+	Tags         map[string]string
+	// VersionID is empty if the versioning of the bucket is not enabled.
+	VersionID string
+}
+
+func newObjectAttributes(info minio.ObjectInfo, location *time.Location) *ObjectAttributes {
+	metadata := make(map[string]string, len(info.UserMetadata))
+	for key, value := range info.UserMetadata {
+		metadata[key] = value
+	}
+
+	return &ObjectAttributes{
+		CacheControl:       info.Metadata.Get("Cache-Control"),
+		ContentDisposition: info.Metadata.Get("Content-Disposition"),
+		ContentEncoding:    info.ContentEncoding,
+		ContentType:        info.ContentType,
+		ETag:               info.ETag,
+		LastModified:       info.LastModified.In(location),
+		Metadata:           metadata,
+		Size:               info.Size,
+		StorageClass:       info.StorageClass,
+		Tags:               make(map[string]string),
+		VersionID:          info.VersionID,
+	}
+}
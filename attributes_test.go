@@ -0,0 +1,46 @@
+package minio
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectAttributes(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Shanghai")
+	assert.Nil(t, err)
+
+	lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	attributes := newObjectAttributes(minio.ObjectInfo{
+		ETag:            "etag",
+		LastModified:    lastModified,
+		Size:            7,
+		ContentType:     "text/plain",
+		ContentEncoding: "gzip",
+		Metadata: http.Header{
+			"Cache-Control":       []string{"max-age=3600"},
+			"Content-Disposition": []string{"attachment"},
+		},
+		UserMetadata: minio.StringMap{"Author": "goravel"},
+		StorageClass: "STANDARD",
+		VersionID:    "1",
+	}, location)
+
+	assert.Equal(t, &ObjectAttributes{
+		CacheControl:       "max-age=3600",
+		ContentDisposition: "attachment",
+		ContentEncoding:    "gzip",
+		ContentType:        "text/plain",
+		ETag:               "etag",
+		LastModified:       lastModified.In(location),
+		Metadata:           map[string]string{"Author": "goravel"},
+		Size:               7,
+		StorageClass:       "STANDARD",
+		Tags:               map[string]string{},
+		VersionID:          "1",
+	}, attributes)
+	assert.Equal(t, location, attributes.LastModified.Location())
+}
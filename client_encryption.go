@@ -0,0 +1,470 @@
+package minio
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// clientEncryptionChunkSize is the size of the plaintext encrypted by each AES-GCM seal, every chunk
+	// is followed by its tag, so the content can be encrypted and decrypted as a stream.
+	clientEncryptionChunkSize       = 64 * 1024
+	clientEncryptionNoncePrefixSize = 7
+
+	// The user metadata of an encrypted object.
+	metadataEncryptionKey   = "Goravel-Encryption-Key"
+	metadataEncryptionKeyID = "Goravel-Encryption-Key-Id"
+	metadataEncryptionNonce = "Goravel-Encryption-Nonce"
+)
+
+// clientEncryption encrypts the content of objects before they are uploaded. Every object is encrypted by
+// a random data key, the data key is wrapped by a master key and stored in the metadata of the object, so
+// the master key can be rotated by re-wrapping the data keys without re-uploading the content.
+type clientEncryption struct {
+	// keyID is the id of the master key wrapping the data keys of new objects.
+	keyID string
+	// keys are the master keys by id, the old keys are required to read the objects encrypted by them.
+	keys map[string][]byte
+}
+
+// WithClientEncryption gets a copy of the disk that encrypts the content of the written files on the client
+// side, the storage provider never sees the plaintext. The master keys should be 32 bytes, keyed by their
+// id, keyID is the id of the key used by new files, the other keys are used to read and rotate old files.
+func (r *Minio) WithClientEncryption(keys map[string][]byte, keyID string) (*Minio, error) {
+	encryption, err := newClientEncryptionWithKeys(keys, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := *r
+	driver.clientEncryption = encryption
+
+	return &driver, nil
+}
+
+// RotateEncryptionKey re-wraps the data key of the file by the current master key, the content is not
+// re-uploaded, the object is copied to itself by the server with the new metadata.
+func (r *Minio) RotateEncryptionKey(file string) error {
+	_, err := r.rotateEncryptionKey(file)
+
+	return err
+}
+
+// RotateEncryptionKeys re-wraps the data keys of every file under the directory by the current master key,
+// and returns the number of rotated files, files already wrapped by the current key are skipped.
+func (r *Minio) RotateEncryptionKeys(directory string) (int, error) {
+	rotated := 0
+	for entry, err := range r.List(directory, ListOptions{Recursive: true}) {
+		if err != nil {
+			return rotated, err
+		}
+		if entry.IsDirectory {
+			continue
+		}
+
+		ok, err := r.rotateEncryptionKey(validPath(directory) + entry.Key)
+		if err != nil {
+			return rotated, err
+		}
+		if ok {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
+
+// readDecryptedRange reads the range of the decrypted content, only the chunks covering the range are
+// downloaded and decrypted, since the chunks have a fixed size.
+func (r *Minio) readDecryptedRange(file string, opts minio.GetObjectOptions, offset, length int64) (io.ReadCloser, error) {
+	info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+		ServerSideEncryption: r.readEncryption(),
+	})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if !isClientEncrypted(info.UserMetadata) {
+		return r.getObject(file, opts)
+	}
+	if offset >= decryptedSize(info.Size) {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	const encryptedChunkSize = clientEncryptionChunkSize + 16
+	firstChunk := offset / clientEncryptionChunkSize
+	end := info.Size - 1
+	if length > 0 {
+		end = min(((offset+length-1)/clientEncryptionChunkSize+1)*encryptedChunkSize, info.Size) - 1
+	}
+
+	// The chunks are requested from the same object that is stated, in case it is overwritten meanwhile.
+	opts = minio.GetObjectOptions{ServerSideEncryption: r.readEncryption()}
+	if err := opts.SetRange(firstChunk*encryptedChunkSize, end); err != nil {
+		return nil, err
+	}
+	if err := opts.SetMatchETag(info.ETag); err != nil {
+		return nil, err
+	}
+	object, err := r.instance.GetObject(r.ctx, r.bucket, r.key(file), opts)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if _, err := object.Stat(); err != nil {
+		_ = object.Close()
+
+		return nil, convertError(err)
+	}
+
+	lastChunk := (info.Size - 1) / encryptedChunkSize
+	decrypted, err := r.clientEncryption.decryptChunks(object, info.UserMetadata, uint32(firstChunk), lastChunk)
+	if err != nil {
+		_ = object.Close()
+
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, decrypted, offset-firstChunk*clientEncryptionChunkSize); err != nil {
+		_ = object.Close()
+
+		return nil, err
+	}
+	if length <= 0 {
+		return &readCloser{Reader: decrypted, Closer: object}, nil
+	}
+
+	return &readCloser{Reader: io.LimitReader(decrypted, length), Closer: object}, nil
+}
+
+func (r *Minio) rotateEncryptionKey(file string) (bool, error) {
+	if r.clientEncryption == nil {
+		return false, errors.New("the client encryption is not enabled")
+	}
+
+	info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+		ServerSideEncryption: r.readEncryption(),
+	})
+	if err != nil {
+		return false, convertError(err)
+	}
+	if !isClientEncrypted(info.UserMetadata) || info.UserMetadata[metadataEncryptionKeyID] == r.clientEncryption.keyID {
+		return false, nil
+	}
+
+	dataKey, err := r.clientEncryption.unwrap(info.UserMetadata)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwrap the data key of %s: %w", file, err)
+	}
+	wrapped, err := r.clientEncryption.wrap(dataKey)
+	if err != nil {
+		return false, err
+	}
+
+	metadata := maps.Clone(info.UserMetadata)
+	metadata[metadataEncryptionKey] = wrapped
+	metadata[metadataEncryptionKeyID] = r.clientEncryption.keyID
+
+	return true, r.copyTo(file, r, file, &putOptions{metadata: metadata})
+}
+
+func newClientEncryption(config config.Config, disk string) (*clientEncryption, error) {
+	prefix := fmt.Sprintf("filesystems.disks.%s.client_encryption", disk)
+	configKeys := config.Get(prefix + ".keys")
+	if configKeys == nil {
+		return nil, nil
+	}
+
+	encodedKeys := make(map[string]string)
+	switch value := configKeys.(type) {
+	case map[string]string:
+		encodedKeys = value
+	case map[string]any:
+		for id, key := range value {
+			encodedKeys[id] = fmt.Sprint(key)
+		}
+	default:
+		return nil, fmt.Errorf("the client encryption keys of %s disk should be a map of key id to base64 encoded key", disk)
+	}
+	if len(encodedKeys) == 0 {
+		return nil, nil
+	}
+
+	keys := make(map[string][]byte, len(encodedKeys))
+	for id, encodedKey := range encodedKeys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("the client encryption key %s of %s disk should be base64 encoded: %w", id, disk, err)
+		}
+		keys[id] = key
+	}
+
+	return newClientEncryptionWithKeys(keys, config.GetString(prefix+".key_id"))
+}
+
+func newClientEncryptionWithKeys(keys map[string][]byte, keyID string) (*clientEncryption, error) {
+	if _, ok := keys[keyID]; !ok {
+		return nil, fmt.Errorf("the client encryption key %s is not found", keyID)
+	}
+	for id, key := range keys {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("the client encryption key %s should be 32 bytes, got %d bytes", id, len(key))
+		}
+	}
+
+	return &clientEncryption{
+		keyID: keyID,
+		keys:  maps.Clone(keys),
+	}, nil
+}
+
+// encrypt returns a reader of the encrypted content and its size, and the metadata that should be stored
+// with the object.
+func (r *clientEncryption) encrypt(reader io.Reader, size int64) (io.Reader, int64, map[string]string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, 0, nil, err
+	}
+	noncePrefix := make([]byte, clientEncryptionNoncePrefixSize)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, 0, nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	wrapped, err := r.wrap(dataKey)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	if size >= 0 {
+		size = encryptedSize(size)
+	}
+
+	encryptReader := &chunkReader{
+		chunkSize: clientEncryptionChunkSize,
+		source:    reader,
+		transform: func(chunk []byte, counter uint32, last bool) ([]byte, error) {
+			return aead.Seal(chunk[:0], chunkNonce(noncePrefix, counter, last), chunk, nil), nil
+		},
+	}
+
+	return encryptReader, size, map[string]string{
+		metadataEncryptionKey:   wrapped,
+		metadataEncryptionKeyID: r.keyID,
+		metadataEncryptionNonce: base64.StdEncoding.EncodeToString(noncePrefix),
+	}, nil
+}
+
+// decrypt returns a reader of the decrypted content of an object encrypted by encrypt.
+func (r *clientEncryption) decrypt(reader io.Reader, metadata map[string]string) (io.Reader, error) {
+	return r.decryptChunks(reader, metadata, 0, -1)
+}
+
+// decryptChunks returns a reader of the decrypted content of the chunks starting at firstChunk. lastChunk is
+// the index of the last chunk of the object, the reader may end before it if a range is read, it's -1 if
+// the last chunk is the last one of the reader.
+func (r *clientEncryption) decryptChunks(reader io.Reader, metadata map[string]string, firstChunk uint32, lastChunk int64) (io.Reader, error) {
+	dataKey, err := r.unwrap(metadata)
+	if err != nil {
+		return nil, err
+	}
+	noncePrefix, err := base64.StdEncoding.DecodeString(metadata[metadataEncryptionNonce])
+	if err != nil || len(noncePrefix) != clientEncryptionNoncePrefixSize {
+		return nil, errors.New("invalid nonce of the encrypted object")
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chunkReader{
+		chunkSize: clientEncryptionChunkSize + aead.Overhead(),
+		counter:   firstChunk,
+		source:    reader,
+		transform: func(chunk []byte, counter uint32, last bool) ([]byte, error) {
+			if lastChunk >= 0 {
+				last = int64(counter) == lastChunk
+			}
+
+			// The last chunk is sealed with a different nonce, so a truncated content fails to be decrypted.
+			plaintext, err := aead.Open(chunk[:0], chunkNonce(noncePrefix, counter, last), chunk, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt the content: %w", err)
+			}
+
+			return plaintext, nil
+		},
+	}, nil
+}
+
+// wrap encrypts the data key by the current master key, the key id is authenticated as well.
+func (r *clientEncryption) wrap(dataKey []byte) (string, error) {
+	aead, err := newAEAD(r.keys[r.keyID])
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, dataKey, []byte(r.keyID))), nil
+}
+
+func (r *clientEncryption) unwrap(metadata map[string]string) ([]byte, error) {
+	keyID := metadata[metadataEncryptionKeyID]
+	key, ok := r.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("the client encryption key %s is not found", keyID)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(metadata[metadataEncryptionKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key of the encrypted object: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errors.New("invalid data key of the encrypted object")
+	}
+
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], []byte(keyID))
+}
+
+// equal determines if the objects encrypted by r can be read by encryption.
+func (r *clientEncryption) equal(encryption *clientEncryption) bool {
+	if r == nil || encryption == nil {
+		return r == encryption
+	}
+
+	return r.keyID == encryption.keyID && maps.EqualFunc(r.keys, encryption.keys, bytes.Equal)
+}
+
+// chunkReader transforms the content of the source chunk by chunk, the last chunk is determined by
+// reading a byte ahead.
+type chunkReader struct {
+	chunkSize int
+	source    io.Reader
+	transform func(chunk []byte, counter uint32, last bool) ([]byte, error)
+	counter   uint32
+	buffer    []byte
+	next      []byte
+	done      bool
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+
+	return n, nil
+}
+
+func (r *chunkReader) fill() error {
+	chunk := make([]byte, r.chunkSize, r.chunkSize+16)
+	n := copy(chunk, r.next)
+	read, err := io.ReadFull(r.source, chunk[n:])
+	n += read
+
+	last := false
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		last = true
+	} else if err != nil {
+		return err
+	} else {
+		next := make([]byte, 1)
+		read, err := io.ReadFull(r.source, next)
+		if errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+		r.next = next[:read]
+	}
+
+	buffer, err := r.transform(chunk[:n], r.counter, last)
+	if err != nil {
+		return err
+	}
+	r.buffer = buffer
+	r.counter++
+	r.done = last
+
+	return nil
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, 12)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+// encryptedSize gets the size of the content encrypted by encrypt, every chunk, at least one, has a tag.
+func encryptedSize(size int64) int64 {
+	chunks := max((size+clientEncryptionChunkSize-1)/clientEncryptionChunkSize, 1)
+
+	return size + chunks*16
+}
+
+// decryptedSize is the inverse of encryptedSize.
+func decryptedSize(size int64) int64 {
+	chunks := (size + clientEncryptionChunkSize + 16 - 1) / (clientEncryptionChunkSize + 16)
+
+	return max(size-chunks*16, 0)
+}
+
+func isClientEncrypted(metadata map[string]string) bool {
+	return metadata[metadataEncryptionKey] != ""
+}
+
+// withoutEncryptionMetadata gets a copy of the metadata without the metadata of the client encryption.
+func withoutEncryptionMetadata(metadata map[string]string) map[string]string {
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, "Goravel-Encryption-") {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// readCloser combines a reader with the closer of the underlying object.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,154 @@
+package minio
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientEncryption(t *testing.T) {
+	encryption, err := newClientEncryptionWithKeys(map[string][]byte{"1": bytes.Repeat([]byte("a"), 32)}, "1")
+	assert.Nil(t, err)
+
+	for _, size := range []int{0, 1, clientEncryptionChunkSize - 1, clientEncryptionChunkSize, clientEncryptionChunkSize + 1, 3 * clientEncryptionChunkSize} {
+		content := make([]byte, size)
+		_, err := rand.Read(content)
+		assert.Nil(t, err)
+
+		reader, encryptedSize, metadata, err := encryption.encrypt(bytes.NewReader(content), int64(size))
+		assert.Nil(t, err)
+		encrypted, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, encryptedSize, int64(len(encrypted)))
+		assert.Equal(t, int64(size), decryptedSize(encryptedSize))
+		assert.Equal(t, "1", metadata[metadataEncryptionKeyID])
+
+		reader, err = encryption.decrypt(bytes.NewReader(encrypted), metadata)
+		assert.Nil(t, err)
+		decrypted, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(content, decrypted))
+
+		// Truncated content
+		reader, err = encryption.decrypt(bytes.NewReader(encrypted[:len(encrypted)-1]), metadata)
+		assert.Nil(t, err)
+		_, err = io.ReadAll(reader)
+		assert.NotNil(t, err)
+	}
+
+	// Chunks of a range
+	content := make([]byte, 3*clientEncryptionChunkSize+100)
+	_, err = rand.Read(content)
+	assert.Nil(t, err)
+	reader, _, metadata, err := encryption.encrypt(bytes.NewReader(content), int64(len(content)))
+	assert.Nil(t, err)
+	encrypted, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	const encryptedChunkSize = clientEncryptionChunkSize + 16
+
+	reader, err = encryption.decryptChunks(bytes.NewReader(encrypted[encryptedChunkSize:3*encryptedChunkSize]), metadata, 1, 3)
+	assert.Nil(t, err)
+	decrypted, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.True(t, bytes.Equal(content[clientEncryptionChunkSize:3*clientEncryptionChunkSize], decrypted))
+
+	reader, err = encryption.decryptChunks(bytes.NewReader(encrypted[3*encryptedChunkSize:]), metadata, 3, 3)
+	assert.Nil(t, err)
+	decrypted, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.True(t, bytes.Equal(content[3*clientEncryptionChunkSize:], decrypted))
+
+	// A chunk that is not the last one can't be passed off as it
+	reader, err = encryption.decryptChunks(bytes.NewReader(encrypted[:3*encryptedChunkSize]), metadata, 0, 2)
+	assert.Nil(t, err)
+	_, err = io.ReadAll(reader)
+	assert.NotNil(t, err)
+
+	reader, size, metadata, err := encryption.encrypt(strings.NewReader("Goravel"), -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), size)
+	encrypted, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+
+	// Tampered content
+	tampered := bytes.Clone(encrypted)
+	tampered[0] ^= 1
+	reader, err = encryption.decrypt(bytes.NewReader(tampered), metadata)
+	assert.Nil(t, err)
+	_, err = io.ReadAll(reader)
+	assert.NotNil(t, err)
+
+	// Rotated master key
+	rotated, err := newClientEncryptionWithKeys(map[string][]byte{"1": bytes.Repeat([]byte("a"), 32), "2": bytes.Repeat([]byte("b"), 32)}, "2")
+	assert.Nil(t, err)
+	dataKey, err := rotated.unwrap(metadata)
+	assert.Nil(t, err)
+	metadata[metadataEncryptionKey], err = rotated.wrap(dataKey)
+	assert.Nil(t, err)
+	metadata[metadataEncryptionKeyID] = "2"
+	reader, err = rotated.decrypt(bytes.NewReader(encrypted), metadata)
+	assert.Nil(t, err)
+	decrypted, err = io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, "Goravel", string(decrypted))
+
+	_, err = encryption.decrypt(bytes.NewReader(encrypted), metadata)
+	assert.NotNil(t, err)
+
+	assert.True(t, encryption.equal(encryption))
+	assert.False(t, encryption.equal(rotated))
+	assert.False(t, encryption.equal(nil))
+	assert.True(t, (*clientEncryption)(nil).equal(nil))
+}
+
+func TestClientEncryption_Presign(t *testing.T) {
+	encryption, err := newClientEncryptionWithKeys(map[string][]byte{"1": bytes.Repeat([]byte("a"), 32)}, "1")
+	assert.Nil(t, err)
+	driver := &Minio{clientEncryption: encryption}
+
+	_, err = driver.TemporaryUploadUrl("1.txt", time.Now().Add(time.Hour), TemporaryUploadOptions{})
+	assert.ErrorIs(t, err, errPresignClientEncryption)
+	_, err = driver.TemporaryUrlFor("1.txt", time.Hour, TemporaryUrlOptions{})
+	assert.ErrorIs(t, err, errPresignClientEncryption)
+	_, err = driver.TemporaryUrl("1.txt", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, errPresignClientEncryption)
+	_, err = driver.PresignedPostPolicy().Expires(time.Now().Add(time.Hour)).Key("1.txt").Presign()
+	assert.ErrorIs(t, err, errPresignClientEncryption)
+}
+
+func TestNewClientEncryption(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("a"), 32))
+
+	mockConfig := configmock.NewConfig(t)
+	mockConfig.EXPECT().Get("filesystems.disks.minio.client_encryption.keys").Return(nil).Once()
+	encryption, err := newClientEncryption(mockConfig, "minio")
+	assert.Nil(t, err)
+	assert.Nil(t, encryption)
+
+	mockConfig = configmock.NewConfig(t)
+	mockConfig.EXPECT().Get("filesystems.disks.minio.client_encryption.keys").Return(map[string]any{"1": key}).Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.client_encryption.key_id").Return("1").Once()
+	encryption, err = newClientEncryption(mockConfig, "minio")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", encryption.keyID)
+	assert.Equal(t, bytes.Repeat([]byte("a"), 32), encryption.keys["1"])
+
+	mockConfig = configmock.NewConfig(t)
+	mockConfig.EXPECT().Get("filesystems.disks.minio.client_encryption.keys").Return(map[string]string{"1": key}).Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.client_encryption.key_id").Return("2").Once()
+	_, err = newClientEncryption(mockConfig, "minio")
+	assert.NotNil(t, err)
+
+	mockConfig = configmock.NewConfig(t)
+	mockConfig.EXPECT().Get("filesystems.disks.minio.client_encryption.keys").Return(map[string]string{"1": base64.StdEncoding.EncodeToString([]byte("short"))}).Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.client_encryption.key_id").Return("1").Once()
+	_, err = newClientEncryption(mockConfig, "minio")
+	assert.NotNil(t, err)
+}
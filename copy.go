@@ -0,0 +1,186 @@
+package minio
+
+import (
+	"maps"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// maxCopyObjectSize is the maximum size of the source of a single CopyObject request, larger objects
+// are copied part by part by the server.
+const maxCopyObjectSize = 5 << 30
+
+// CopyTo copies the file to another disk or bucket, see WithBucket. The object is copied by the server
+// if both disks share the endpoint and credentials, otherwise it's streamed from this disk to the target
+// disk. The content type, headers, metadata and tags are kept, they can be replaced via the options.
+func (r *Minio) CopyTo(file string, target *Minio, targetFile string, options ...PutOption) error {
+	opts := newPutOptions(options)
+	if err := target.putVisibility(targetFile, opts); err != nil {
+		return err
+	}
+
+	return r.copyTo(file, target, targetFile, opts)
+}
+
+// CopyWithOptions copies the file, the headers, metadata and tags of the origin file are replaced by
+// the given options. WithMetadata and WithTags replace all the metadata and tags of the origin file.
+func (r *Minio) CopyWithOptions(originFile, targetFile string, options ...PutOption) error {
+	return r.CopyTo(originFile, r, targetFile, options...)
+}
+
+// MoveTo moves the file to another disk or bucket, the file is deleted after being copied by CopyTo.
+func (r *Minio) MoveTo(file string, target *Minio, targetFile string, options ...PutOption) error {
+	if err := r.CopyTo(file, target, targetFile, options...); err != nil {
+		return err
+	}
+
+	return r.Delete(file)
+}
+
+func (r *Minio) copyTo(file string, target *Minio, targetFile string, opts *putOptions) error {
+	// The encrypted content is copied as it is only if the target can decrypt it.
+	if r.sameServer(target) && r.clientEncryption.equal(target.clientEncryption) {
+		// The SSE-C key of the source is sent as a copy source header by minio-go.
+		srcOpts := minio.CopySrcOptions{
+			Bucket:     r.bucket,
+			Object:     r.key(file),
+			Encryption: r.readEncryption(),
+		}
+		dstOpts := minio.CopyDestOptions{
+			Bucket:     target.bucket,
+			Object:     target.key(targetFile),
+			Encryption: target.encryption,
+		}
+
+		info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+			ServerSideEncryption: r.readEncryption(),
+		})
+		if err != nil {
+			return convertError(err)
+		}
+
+		// CopyObject keeps the headers, metadata and tags of the origin file.
+		if info.Size <= maxCopyObjectSize && !opts.replaces() {
+			_, err := r.instance.CopyObject(r.ctx, dstOpts, srcOpts)
+
+			return convertError(err)
+		}
+
+		return r.composeObject(file, info, srcOpts, dstOpts, opts)
+	}
+
+	info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+		ServerSideEncryption: r.readEncryption(),
+	})
+	if err != nil {
+		return convertError(err)
+	}
+
+	destination, err := r.copyOptions(file, info, opts)
+	if err != nil {
+		return err
+	}
+	// The content is decrypted by ReadStream and encrypted again by the target if needed.
+	destination.metadata = withoutEncryptionMetadata(destination.metadata)
+	size := info.Size
+	if isClientEncrypted(info.UserMetadata) {
+		size = decryptedSize(size)
+	}
+
+	object, err := r.ReadStream(file)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	return target.PutStream(targetFile, object, size, func(options *putOptions) {
+		*options = *destination
+	})
+}
+
+// composeObject copies the object by the server part by part if it is larger than 5 GiB. The multipart upload
+// created by ComposeObject doesn't keep anything of the origin object, so the headers, metadata and tags are
+// set explicitly.
+func (r *Minio) composeObject(file string, info minio.ObjectInfo, srcOpts minio.CopySrcOptions, dstOpts minio.CopyDestOptions, opts *putOptions) error {
+	destination, err := r.copyOptions(file, info, opts)
+	if err != nil {
+		return err
+	}
+	dstOpts.ReplaceMetadata = true
+	dstOpts.UserMetadata = destination.headers()
+	dstOpts.ReplaceTags = true
+	dstOpts.UserTags = destination.tags
+	_, err = r.instance.ComposeObject(r.ctx, dstOpts, srcOpts)
+
+	return convertError(err)
+}
+
+// copyOptions merges the headers, metadata and tags of the origin file with the options, the ACL is kept
+// but the visibility is not included.
+func (r *Minio) copyOptions(file string, info minio.ObjectInfo, opts *putOptions) (*putOptions, error) {
+	destination := &putOptions{
+		acl:                opts.acl,
+		cacheControl:       info.Metadata.Get("Cache-Control"),
+		contentDisposition: info.Metadata.Get("Content-Disposition"),
+		contentEncoding:    info.Metadata.Get("Content-Encoding"),
+		contentType:        info.ContentType,
+		metadata:           info.UserMetadata,
+		tags:               opts.tags,
+	}
+	if opts.cacheControl != "" {
+		destination.cacheControl = opts.cacheControl
+	}
+	if opts.contentDisposition != "" {
+		destination.contentDisposition = opts.contentDisposition
+	}
+	if opts.contentEncoding != "" {
+		destination.contentEncoding = opts.contentEncoding
+	}
+	if opts.contentType != "" {
+		destination.contentType = opts.contentType
+	}
+	if opts.metadata != nil {
+		destination.metadata = opts.metadata
+		// The data key of the client encryption must be kept, otherwise the copy can't be decrypted.
+		if isClientEncrypted(info.UserMetadata) && !isClientEncrypted(opts.metadata) {
+			destination.metadata = maps.Clone(opts.metadata)
+			for _, key := range []string{metadataEncryptionKey, metadataEncryptionKeyID, metadataEncryptionNonce} {
+				destination.metadata[key] = info.UserMetadata[key]
+			}
+		}
+	}
+
+	if destination.tags == nil && info.UserTagCount > 0 {
+		objectTags, err := r.instance.GetObjectTagging(r.ctx, r.bucket, r.key(file), minio.GetObjectTaggingOptions{
+			VersionID: info.VersionID,
+		})
+		if err != nil {
+			return nil, convertError(err)
+		}
+		destination.tags = objectTags.ToMap()
+	}
+
+	return destination, nil
+}
+
+// sameServer determines if the objects of the target disk can be copied by the server, the disks should
+// share the endpoint and credentials.
+func (r *Minio) sameServer(target *Minio) bool {
+	if r.instance == target.instance {
+		return true
+	}
+	if r.instance.EndpointURL().String() != target.instance.EndpointURL().String() {
+		return false
+	}
+
+	source, err := r.instance.GetCreds()
+	if err != nil {
+		return false
+	}
+	destination, err := target.instance.GetCreds()
+	if err != nil {
+		return false
+	}
+
+	return source.AccessKeyID == destination.AccessKeyID && source.SecretAccessKey == destination.SecretAccessKey
+}
@@ -0,0 +1,35 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameServer(t *testing.T) {
+	newDriver := func(endpoint, key string) *Minio {
+		client, err := minio.New(endpoint, &minio.Options{
+			Creds: credentials.NewStaticV4(key, "secret", ""),
+		})
+		assert.Nil(t, err)
+
+		return &Minio{instance: client, bucket: "goravel"}
+	}
+
+	driver := newDriver("127.0.0.1:9000", "key")
+	assert.True(t, driver.sameServer(driver))
+	assert.True(t, driver.sameServer(driver.WithBucket("archive")))
+	assert.True(t, driver.sameServer(newDriver("127.0.0.1:9000", "key")))
+	assert.False(t, driver.sameServer(newDriver("127.0.0.1:9001", "key")))
+	assert.False(t, driver.sameServer(newDriver("127.0.0.1:9000", "another")))
+}
+
+func TestWithBucket(t *testing.T) {
+	driver := &Minio{bucket: "goravel", url: "http://127.0.0.1:9000/goravel/"}
+	archive := driver.WithBucket("archive")
+	assert.Equal(t, "archive", archive.bucket)
+	assert.Equal(t, "http://127.0.0.1:9000/archive/1.txt", archive.Url("1.txt"))
+	assert.Equal(t, "goravel", driver.bucket)
+}
@@ -0,0 +1,144 @@
+package minio
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	// CredentialsStatic uses the key, secret and optional session token of the disk.
+	CredentialsStatic = "static"
+	// CredentialsEnv reads AWS_* or MINIO_* environment variables.
+	CredentialsEnv = "env"
+	// CredentialsFile reads a shared credentials file, ~/.aws/credentials by default.
+	CredentialsFile = "file"
+	// CredentialsIAM fetches the credentials of the IAM role from the instance metadata service.
+	CredentialsIAM = "iam"
+	// CredentialsSTS assumes a role via STS AssumeRole with the key and secret of the disk.
+	CredentialsSTS = "sts"
+	// CredentialsWebIdentity assumes a role via STS AssumeRoleWithWebIdentity with a token file.
+	CredentialsWebIdentity = "web_identity"
+	// CredentialsChain tries the configured providers in order until one returns credentials.
+	CredentialsChain = "chain"
+)
+
+// newCredentials builds the credentials of the disk from the `credentials` block of the disk configuration,
+// the static key and secret of the disk are used if the block is not set. Temporary credentials are
+// refreshed automatically by minio-go before they expire.
+func newCredentials(config config.Config, disk string) (*credentials.Credentials, error) {
+	provider, err := newCredentialsProvider(config, disk, config.GetString(fmt.Sprintf("filesystems.disks.%s.credentials.provider", disk), CredentialsStatic))
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.New(provider), nil
+}
+
+func newCredentialsProvider(config config.Config, disk, name string) (credentials.Provider, error) {
+	prefix := fmt.Sprintf("filesystems.disks.%s", disk)
+
+	switch name {
+	case CredentialsStatic:
+		key := config.GetString(prefix + ".key")
+		secret := config.GetString(prefix + ".secret")
+		if key == "" || secret == "" {
+			return nil, fmt.Errorf("please set the key and secret of %s disk first", disk)
+		}
+
+		return &credentials.Static{
+			Value: credentials.Value{
+				AccessKeyID:     key,
+				SecretAccessKey: secret,
+				SessionToken:    config.GetString(prefix + ".credentials.token"),
+				SignerType:      credentials.SignatureV4,
+			},
+		}, nil
+	case CredentialsEnv:
+		return &credentials.Chain{
+			Providers: []credentials.Provider{
+				&credentials.EnvAWS{},
+				&credentials.EnvMinio{},
+			},
+		}, nil
+	case CredentialsFile:
+		return &credentials.FileAWSCredentials{
+			Filename: config.GetString(prefix + ".credentials.file"),
+			Profile:  config.GetString(prefix + ".credentials.profile"),
+		}, nil
+	case CredentialsIAM:
+		return &credentials.IAM{
+			Endpoint: config.GetString(prefix + ".credentials.endpoint"),
+			Region:   config.GetString(prefix + ".region"),
+		}, nil
+	case CredentialsSTS:
+		key := config.GetString(prefix + ".key")
+		secret := config.GetString(prefix + ".secret")
+		endpoint := config.GetString(prefix + ".credentials.sts_endpoint")
+		if key == "" || secret == "" || endpoint == "" {
+			return nil, fmt.Errorf("please set the key, secret and credentials.sts_endpoint of %s disk first", disk)
+		}
+
+		return &credentials.STSAssumeRole{
+			STSEndpoint: endpoint,
+			Options: credentials.STSAssumeRoleOptions{
+				AccessKey:       key,
+				SecretKey:       secret,
+				SessionToken:    config.GetString(prefix + ".credentials.token"),
+				Location:        config.GetString(prefix + ".region"),
+				DurationSeconds: config.GetInt(prefix + ".credentials.duration"),
+				RoleARN:         config.GetString(prefix + ".credentials.role_arn"),
+				RoleSessionName: config.GetString(prefix + ".credentials.role_session_name"),
+				ExternalID:      config.GetString(prefix + ".credentials.external_id"),
+			},
+		}, nil
+	case CredentialsWebIdentity:
+		endpoint := config.GetString(prefix + ".credentials.sts_endpoint")
+		tokenFile := config.GetString(prefix + ".credentials.token_file")
+		if endpoint == "" || tokenFile == "" {
+			return nil, fmt.Errorf("please set the credentials.sts_endpoint and credentials.token_file of %s disk first", disk)
+		}
+
+		return &credentials.STSWebIdentity{
+			STSEndpoint: endpoint,
+			RoleARN:     config.GetString(prefix + ".credentials.role_arn"),
+			// The token file is read on every refresh, it may be rotated by the platform.
+			GetWebIDTokenExpiry: func() (*credentials.WebIdentityToken, error) {
+				token, err := os.ReadFile(tokenFile)
+				if err != nil {
+					return nil, err
+				}
+
+				return &credentials.WebIdentityToken{
+					Token: string(token),
+				}, nil
+			},
+		}, nil
+	case CredentialsChain:
+		names := config.GetStringSlice(prefix + ".credentials.chain")
+		if len(names) == 0 {
+			return nil, fmt.Errorf("please set the credentials.chain of %s disk first", disk)
+		}
+
+		var providers []credentials.Provider
+		for _, item := range names {
+			if item == CredentialsChain {
+				return nil, fmt.Errorf("the credentials.chain of %s disk can't contain %s", disk, CredentialsChain)
+			}
+
+			provider, err := newCredentialsProvider(config, disk, item)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+
+		return &credentials.Chain{
+			Providers: providers,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentials provider %s of %s disk", name, disk)
+	}
+}
@@ -0,0 +1,151 @@
+package minio
+
+import (
+	"os"
+	"testing"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCredentials(t *testing.T) {
+	mockConfig := configmock.NewConfig(t)
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.provider", CredentialsStatic).Return(CredentialsStatic).Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.key").Return("key").Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.secret").Return("secret").Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.token").Return("token").Once()
+
+	creds, err := newCredentials(mockConfig, "minio")
+	assert.Nil(t, err)
+	value, err := creds.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "key", value.AccessKeyID)
+	assert.Equal(t, "secret", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+}
+
+func TestNewCredentialsProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		setup     func(mockConfig *configmock.Config)
+		assert    func(provider credentials.Provider)
+		expectErr bool
+	}{
+		{
+			name:     "static without key",
+			provider: CredentialsStatic,
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.key").Return("").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.secret").Return("secret").Once()
+			},
+			expectErr: true,
+		},
+		{
+			name:     "env",
+			provider: CredentialsEnv,
+			setup:    func(mockConfig *configmock.Config) {},
+			assert: func(provider credentials.Provider) {
+				value, err := provider.Retrieve()
+				assert.Nil(t, err)
+				assert.Equal(t, "env-key", value.AccessKeyID)
+				assert.Equal(t, "env-secret", value.SecretAccessKey)
+			},
+		},
+		{
+			name:     "sts without endpoint",
+			provider: CredentialsSTS,
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.key").Return("key").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.secret").Return("secret").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.sts_endpoint").Return("").Once()
+			},
+			expectErr: true,
+		},
+		{
+			name:     "web identity without token file",
+			provider: CredentialsWebIdentity,
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.sts_endpoint").Return("https://sts.amazonaws.com").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.token_file").Return("").Once()
+			},
+			expectErr: true,
+		},
+		{
+			name:     "chain",
+			provider: CredentialsChain,
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetStringSlice("filesystems.disks.minio.credentials.chain").Return([]string{CredentialsEnv, CredentialsStatic}).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.key").Return("key").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.secret").Return("secret").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.token").Return("").Once()
+			},
+			assert: func(provider credentials.Provider) {
+				chain, ok := provider.(*credentials.Chain)
+				assert.True(t, ok)
+				assert.Len(t, chain.Providers, 2)
+
+				value, err := provider.Retrieve()
+				assert.Nil(t, err)
+				assert.Equal(t, "env-key", value.AccessKeyID)
+			},
+		},
+		{
+			name:     "nested chain",
+			provider: CredentialsChain,
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetStringSlice("filesystems.disks.minio.credentials.chain").Return([]string{CredentialsChain}).Once()
+			},
+			expectErr: true,
+		},
+		{
+			name:      "unsupported",
+			provider:  "unknown",
+			setup:     func(mockConfig *configmock.Config) {},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("AWS_ACCESS_KEY_ID", "env-key")
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+			t.Setenv("AWS_SESSION_TOKEN", "")
+
+			mockConfig := configmock.NewConfig(t)
+			test.setup(mockConfig)
+
+			provider, err := newCredentialsProvider(mockConfig, "minio", test.provider)
+			if test.expectErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, provider)
+				return
+			}
+
+			assert.Nil(t, err)
+			test.assert(provider)
+		})
+	}
+}
+
+func TestNewCredentialsProvider_WebIdentity(t *testing.T) {
+	tokenFile := t.TempDir() + "/token"
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("token"), 0644))
+
+	mockConfig := configmock.NewConfig(t)
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.sts_endpoint").Return("https://sts.amazonaws.com").Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.token_file").Return(tokenFile).Once()
+	mockConfig.EXPECT().GetString("filesystems.disks.minio.credentials.role_arn").Return("arn:aws:iam::123456789012:role/goravel").Once()
+
+	provider, err := newCredentialsProvider(mockConfig, "minio", CredentialsWebIdentity)
+	assert.Nil(t, err)
+
+	webIdentity, ok := provider.(*credentials.STSWebIdentity)
+	assert.True(t, ok)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/goravel", webIdentity.RoleARN)
+
+	token, err := webIdentity.GetWebIDTokenExpiry()
+	assert.Nil(t, err)
+	assert.Equal(t, "token", token.Token)
+}
@@ -0,0 +1,147 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// DeleteDirectoryOptions configures DeleteDirectoryWithOptions.
+type DeleteDirectoryOptions struct {
+	// BypassGovernance deletes the versions protected by a retention of RetentionGovernance mode, it
+	// requires the s3:BypassGovernanceRetention permission.
+	BypassGovernance bool
+	// DryRun counts the objects under the directory without deleting them.
+	DryRun bool
+	// Versions deletes every version and delete marker of the objects permanently, otherwise versioned
+	// buckets keep the previous versions and add a delete marker.
+	Versions bool
+}
+
+// DeleteDirectoryResult reports the result of DeleteDirectoryWithOptions.
+type DeleteDirectoryResult struct {
+	// Deleted is the number of deleted objects, or versions if DeleteDirectoryOptions.Versions is enabled.
+	// It's the number of objects that would be deleted in dry-run mode.
+	Deleted int
+	// Failed is the number of objects failed to be deleted.
+	Failed int
+}
+
+// DeleteOptions configures DeleteWithOptions.
+type DeleteOptions struct {
+	// Strict treats missing files as failures. S3 reports deleting a missing object as a success, so every
+	// file is checked before being deleted, it costs a request per file.
+	Strict bool
+}
+
+// DeleteDirectoryWithOptions deletes every object under the directory, the objects are listed and removed
+// in batches, so it works with any S3 compatible service instead of relying on the force delete of MinIO.
+func (r *Minio) DeleteDirectoryWithOptions(directory string, options DeleteDirectoryOptions) (*DeleteDirectoryResult, error) {
+	if validPath(directory) == "" {
+		return nil, errors.New("the directory is required, deleting the whole disk is not allowed")
+	}
+	prefix := r.root + validPath(directory)
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+
+	objects := r.instance.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithVersions: options.Versions,
+	})
+
+	result := &DeleteDirectoryResult{}
+	if options.DryRun {
+		for object := range objects {
+			if object.Err != nil {
+				return nil, convertError(object.Err)
+			}
+			result.Deleted++
+		}
+
+		return result, nil
+	}
+
+	var listErr error
+	sent := 0
+	done := make(chan struct{})
+	objectsCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(done)
+		defer close(objectsCh)
+
+		for object := range objects {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			select {
+			case objectsCh <- object:
+				sent++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var failures []*DeleteObjectError
+	for removeObjectError := range r.instance.RemoveObjects(ctx, r.bucket, objectsCh, minio.RemoveObjectsOptions{
+		GovernanceBypass: options.BypassGovernance,
+	}) {
+		failures = append(failures, newDeleteObjectError(strings.TrimPrefix(removeObjectError.ObjectName, r.root), removeObjectError.VersionID, removeObjectError.Err))
+	}
+	cancel()
+	<-done
+
+	result.Failed = len(failures)
+	result.Deleted = max(sent-result.Failed, 0)
+	if listErr != nil {
+		return result, convertError(listErr)
+	}
+	if len(failures) > 0 {
+		return result, &DeleteError{Errors: failures}
+	}
+
+	return result, nil
+}
+
+// DeleteWithOptions deletes the files in batches, every failure is reported by a *DeleteError instead of
+// the first one only.
+func (r *Minio) DeleteWithOptions(files []string, options DeleteOptions) error {
+	var failures []*DeleteObjectError
+	keys := make(map[string]string, len(files))
+	objectsCh := make(chan minio.ObjectInfo, len(files))
+	for _, file := range files {
+		key := r.key(file)
+		if options.Strict {
+			_, err := r.instance.StatObject(r.ctx, r.bucket, key, minio.StatObjectOptions{
+				ServerSideEncryption: r.readEncryption(),
+			})
+			if errors.Is(convertError(err), ErrNotFound) {
+				failures = append(failures, newDeleteObjectError(file, "", err))
+				continue
+			}
+		}
+		keys[key] = file
+		objectsCh <- minio.ObjectInfo{Key: key}
+	}
+	close(objectsCh)
+
+	// Drain every result, otherwise the goroutine of minio-go is blocked forever.
+	for removeObjectError := range r.instance.RemoveObjects(r.ctx, r.bucket, objectsCh, minio.RemoveObjectsOptions{}) {
+		file, ok := keys[removeObjectError.ObjectName]
+		if !ok {
+			file = strings.TrimPrefix(removeObjectError.ObjectName, r.root)
+		}
+		failures = append(failures, newDeleteObjectError(file, removeObjectError.VersionID, removeObjectError.Err))
+	}
+
+	if len(failures) > 0 {
+		return &DeleteError{Errors: failures}
+	}
+
+	return nil
+}
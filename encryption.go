@@ -0,0 +1,88 @@
+package minio
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	EncryptionSSES3  = "sse-s3"
+	EncryptionSSEKMS = "sse-kms"
+	EncryptionSSEC   = "sse-c"
+)
+
+var (
+	errPresignSSEC             = errors.New("temporary urls and post policies are not supported by SSE-C, the key must be sent via headers by the client")
+	errPresignClientEncryption = errors.New("temporary urls and post policies are not supported by the client encryption, the content would bypass the encryption")
+)
+
+// newEncryption creates the server-side encryption of the disk according to the encryption.mode configuration,
+// the objects are not encrypted by the driver if it is empty.
+func newEncryption(config config.Config, disk string) (encrypt.ServerSide, error) {
+	prefix := fmt.Sprintf("filesystems.disks.%s.encryption", disk)
+
+	switch mode := config.GetString(prefix + ".mode"); mode {
+	case "":
+		return nil, nil
+	case EncryptionSSES3:
+		return encrypt.NewSSE(), nil
+	case EncryptionSSEKMS:
+		keyID := config.GetString(prefix + ".kms_key_id")
+		if keyID == "" {
+			return nil, fmt.Errorf("please set the kms_key_id of %s disk first", disk)
+		}
+
+		return encrypt.NewSSEKMS(keyID, nil)
+	case EncryptionSSEC:
+		key := config.GetString(prefix + ".key")
+		if key == "" {
+			if env := config.GetString(prefix + ".key_env"); env != "" {
+				key = os.Getenv(env)
+			}
+		}
+		if key == "" {
+			return nil, fmt.Errorf("please set the key or key_env of %s disk first", disk)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("the SSE-C key of %s disk should be base64 encoded: %w", disk, err)
+		}
+		if len(decoded) != 32 {
+			return nil, fmt.Errorf("the SSE-C key of %s disk should be 32 bytes, got %d bytes", disk, len(decoded))
+		}
+
+		return encrypt.NewSSEC(decoded)
+	default:
+		return nil, fmt.Errorf("invalid encryption mode %s of %s disk, it should be %s, %s or %s", mode, disk, EncryptionSSES3, EncryptionSSEKMS, EncryptionSSEC)
+	}
+}
+
+// readEncryption gets the encryption that should be sent when reading an object, S3 decrypts SSE-S3 and
+// SSE-KMS objects transparently and rejects their headers on reads, only the SSE-C key is required.
+func (r *Minio) readEncryption() encrypt.ServerSide {
+	if r.encryption != nil && r.encryption.Type() == encrypt.SSEC {
+		return r.encryption
+	}
+
+	return nil
+}
+
+// presignEncryption gets the encryption that should be signed in presigned requests, SSE-C is not supported
+// since the key would be shared with the client, neither is the client encryption, since the client would
+// upload the plaintext to or download the ciphertext from the provider directly.
+func (r *Minio) presignEncryption() (encrypt.ServerSide, error) {
+	if r.clientEncryption != nil {
+		return nil, errPresignClientEncryption
+	}
+	if r.encryption != nil && r.encryption.Type() == encrypt.SSEC {
+		return nil, errPresignSSEC
+	}
+
+	return r.encryption, nil
+}
@@ -0,0 +1,125 @@
+package minio
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEncryption(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+
+	tests := []struct {
+		name      string
+		setup     func(mockConfig *configmock.Config)
+		expected  encrypt.Type
+		expectErr bool
+	}{
+		{
+			name: "none",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return("").Once()
+			},
+		},
+		{
+			name: "sse-s3",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSES3).Once()
+			},
+			expected: encrypt.S3,
+		},
+		{
+			name: "sse-kms",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSEKMS).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.kms_key_id").Return("goravel").Once()
+			},
+			expected: encrypt.KMS,
+		},
+		{
+			name: "sse-kms without key id",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSEKMS).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.kms_key_id").Return("").Once()
+			},
+			expectErr: true,
+		},
+		{
+			name: "sse-c",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSEC).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.key").Return(key).Once()
+			},
+			expected: encrypt.SSEC,
+		},
+		{
+			name: "sse-c from env",
+			setup: func(mockConfig *configmock.Config) {
+				t.Setenv("MINIO_SSE_C_KEY", key)
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSEC).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.key").Return("").Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.key_env").Return("MINIO_SSE_C_KEY").Once()
+			},
+			expected: encrypt.SSEC,
+		},
+		{
+			name: "sse-c with invalid key",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return(EncryptionSSEC).Once()
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.key").Return(base64.StdEncoding.EncodeToString([]byte("short"))).Once()
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid mode",
+			setup: func(mockConfig *configmock.Config) {
+				mockConfig.EXPECT().GetString("filesystems.disks.minio.encryption.mode").Return("unknown").Once()
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := configmock.NewConfig(t)
+			test.setup(mockConfig)
+
+			encryption, err := newEncryption(mockConfig, "minio")
+			if test.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			if test.expected == "" {
+				assert.Nil(t, encryption)
+			} else {
+				assert.Equal(t, test.expected, encryption.Type())
+			}
+		})
+	}
+}
+
+func TestReadEncryption(t *testing.T) {
+	assert.Nil(t, (&Minio{}).readEncryption())
+	assert.Nil(t, (&Minio{encryption: encrypt.NewSSE()}).readEncryption())
+
+	ssec, err := encrypt.NewSSEC([]byte(strings.Repeat("k", 32)))
+	assert.Nil(t, err)
+	driver := &Minio{encryption: ssec}
+	assert.Equal(t, ssec, driver.readEncryption())
+
+	_, err = driver.presignEncryption()
+	assert.ErrorIs(t, err, errPresignSSEC)
+
+	encryption, err := (&Minio{encryption: encrypt.NewSSE()}).presignEncryption()
+	assert.Nil(t, err)
+	headers := make(http.Header)
+	encryption.Marshal(headers)
+	assert.Equal(t, "AES256", headers.Get("X-Amz-Server-Side-Encryption"))
+}
@@ -0,0 +1,121 @@
+package minio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+var (
+	ErrNotFound      = errors.New("file not found")
+	ErrAccessDenied  = errors.New("access denied")
+	ErrBucketMissing = errors.New("bucket does not exist")
+	// ErrObjectLocked is returned when deleting or overwriting an object version protected by its retention
+	// or legal hold, the error of the code is kept as well, for example, ErrAccessDenied.
+	ErrObjectLocked = errors.New("object is locked")
+	// ErrVisibilityShadowed is returned when a file or directory is made private, but it's still public because
+	// the bucket policy grants anonymous read access to a parent directory.
+	ErrVisibilityShadowed = errors.New("visibility is shadowed by a public directory")
+)
+
+// convertError translates the error response of minio into the sentinel errors above, the original
+// error is kept in the chain, so both errors.Is and errors.As(&minio.ErrorResponse{}) work.
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var errResponse minio.ErrorResponse
+	if !errors.As(err, &errResponse) {
+		return err
+	}
+
+	switch errResponse.Code {
+	case minio.NoSuchKey, minio.NoSuchVersion, "NotFound":
+		err = fmt.Errorf("%w: %w", ErrNotFound, err)
+	case minio.AccessDenied:
+		err = fmt.Errorf("%w: %w", ErrAccessDenied, err)
+	case minio.NoSuchBucket:
+		err = fmt.Errorf("%w: %w", ErrBucketMissing, err)
+	}
+
+	if isObjectLocked(errResponse) {
+		err = fmt.Errorf("%w: %w", ErrObjectLocked, err)
+	}
+
+	return err
+}
+
+// isObjectLocked determines if the error is caused by the retention or legal hold of an object, S3 returns
+// AccessDenied and MinIO returns InvalidRequest, so they can only be distinguished by the message.
+func isObjectLocked(errResponse minio.ErrorResponse) bool {
+	message := strings.ToLower(errResponse.Message)
+
+	return errResponse.Code == "ObjectLocked" ||
+		strings.Contains(message, "protected by object lock") ||
+		strings.Contains(message, "worm protected")
+}
+
+// DeleteError aggregates the failures of deleting multiple objects, errors.Is and errors.As check every
+// failure, for example, errors.Is(err, ErrAccessDenied).
+type DeleteError struct {
+	Errors []*DeleteObjectError
+}
+
+func (e *DeleteError) Error() string {
+	failures := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		failures[i] = err.Error()
+	}
+
+	return fmt.Sprintf("failed to delete %d objects: %s", len(e.Errors), strings.Join(failures, "; "))
+}
+
+func (e *DeleteError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// DeleteObjectError is the failure of deleting a single object.
+type DeleteObjectError struct {
+	// Code is the error code of S3, for example, AccessDenied, it's empty if the error isn't returned by S3.
+	Code string
+	Err  error
+	// Key is relative to the root of the disk.
+	Key       string
+	VersionID string
+}
+
+func newDeleteObjectError(key, versionID string, err error) *DeleteObjectError {
+	var errResponse minio.ErrorResponse
+	errors.As(err, &errResponse)
+
+	return &DeleteObjectError{
+		Code:      errResponse.Code,
+		Err:       convertError(err),
+		Key:       key,
+		VersionID: versionID,
+	}
+}
+
+func (e *DeleteObjectError) Error() string {
+	key := e.Key
+	if e.VersionID != "" {
+		key += "@" + e.VersionID
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s (%s): %v", key, e.Code, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %v", key, e.Err)
+}
+
+func (e *DeleteObjectError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,66 @@
+package minio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertError(t *testing.T) {
+	assert.Nil(t, convertError(nil))
+
+	err := errors.New("network error")
+	assert.Equal(t, err, convertError(err))
+
+	err = minio.ErrorResponse{Code: minio.InvalidArgument}
+	assert.Equal(t, err, convertError(err))
+
+	tests := []struct {
+		code     string
+		sentinel error
+	}{
+		{code: minio.NoSuchKey, sentinel: ErrNotFound},
+		{code: minio.NoSuchVersion, sentinel: ErrNotFound},
+		{code: minio.AccessDenied, sentinel: ErrAccessDenied},
+		{code: minio.NoSuchBucket, sentinel: ErrBucketMissing},
+	}
+	for _, test := range tests {
+		err := convertError(minio.ErrorResponse{Code: test.code})
+		assert.ErrorIs(t, err, test.sentinel)
+
+		var errResponse minio.ErrorResponse
+		assert.True(t, errors.As(err, &errResponse))
+		assert.Equal(t, test.code, errResponse.Code)
+	}
+}
+
+func TestConvertError_ObjectLocked(t *testing.T) {
+	err := convertError(minio.ErrorResponse{Code: minio.AccessDenied, Message: "Access Denied because object protected by object lock."})
+	assert.ErrorIs(t, err, ErrObjectLocked)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+
+	err = convertError(minio.ErrorResponse{Code: "InvalidRequest", Message: "Object is WORM protected and cannot be overwritten"})
+	assert.ErrorIs(t, err, ErrObjectLocked)
+	assert.NotErrorIs(t, err, ErrAccessDenied)
+
+	err = convertError(minio.ErrorResponse{Code: minio.AccessDenied, Message: "Access Denied."})
+	assert.NotErrorIs(t, err, ErrObjectLocked)
+}
+
+func TestDeleteError(t *testing.T) {
+	err := &DeleteError{Errors: []*DeleteObjectError{
+		newDeleteObjectError("1.txt", "", minio.ErrorResponse{Code: minio.AccessDenied, Message: "Access Denied."}),
+		newDeleteObjectError("2.txt", "v1", errors.New("network error")),
+	}}
+
+	assert.Equal(t, "failed to delete 2 objects: 1.txt (AccessDenied): access denied: Access Denied.; 2.txt@v1: network error", err.Error())
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	var objectErr *DeleteObjectError
+	assert.True(t, errors.As(err, &objectErr))
+	assert.Equal(t, "1.txt", objectErr.Key)
+	assert.Equal(t, minio.AccessDenied, objectErr.Code)
+}
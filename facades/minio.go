@@ -1,12 +1,10 @@
 package facades
 
 import (
-	"github.com/goravel/framework/contracts/filesystem"
-
 	"github.com/goravel/minio"
 )
 
-func Minio(disk string) (filesystem.Driver, error) {
+func Minio(disk string) (*minio.Minio, error) {
 	instance, err := minio.App.MakeWith(minio.Binding, map[string]any{"disk": disk})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,310 @@
+package minio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// LifecycleRule is a lifecycle rule of the bucket, the actions whose days are 0 are not set.
+type LifecycleRule struct {
+	// ID identifies the rule, it's required, MergeLifecycleRules replaces the existing rule with the same ID.
+	// It's prefixed with the root of the disk in the bucket, so the disks sharing a bucket don't collide.
+	ID string
+	// Prefix is relative to the root of the disk, the rule applies to all the files of the disk if it is empty.
+	Prefix   string
+	Disabled bool
+	// ExpirationDays deletes the current version of the files, a delete marker is created instead
+	// if the versioning of the bucket is enabled.
+	ExpirationDays int
+	// NoncurrentVersionExpirationDays deletes the versions that have been noncurrent for the days.
+	NoncurrentVersionExpirationDays int
+	// AbortIncompleteMultipartUploadDays aborts the multipart uploads that are not completed after the days.
+	AbortIncompleteMultipartUploadDays int
+	// TransitionDays moves the files to TransitionStorageClass, for example, a remote tier of MinIO or
+	// GLACIER of S3, TransitionStorageClass is required if it is set.
+	TransitionDays         int
+	TransitionStorageClass string
+}
+
+// LifecycleRules gets the lifecycle rules of the bucket, the rules outside the root of the disk are excluded.
+func (r *Minio) LifecycleRules() ([]LifecycleRule, error) {
+	configuration, err := r.lifecycleConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []LifecycleRule
+	for _, rule := range configuration.Rules {
+		if !r.ownsLifecycleRule(rule) {
+			continue
+		}
+
+		rules = append(rules, LifecycleRule{
+			ID:                                 strings.TrimPrefix(rule.ID, r.root),
+			Prefix:                             strings.TrimPrefix(lifecyclePrefix(rule), r.root),
+			Disabled:                           rule.Status == "Disabled",
+			ExpirationDays:                     int(rule.Expiration.Days),
+			NoncurrentVersionExpirationDays:    int(rule.NoncurrentVersionExpiration.NoncurrentDays),
+			AbortIncompleteMultipartUploadDays: int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation),
+			TransitionDays:                     int(rule.Transition.Days),
+			TransitionStorageClass:             rule.Transition.StorageClass,
+		})
+	}
+
+	return rules, nil
+}
+
+// MergeLifecycleRules adds the rules to the bucket, the existing rules with the same ID are replaced and
+// the other existing rules, for example, the ones added by mc, are kept.
+func (r *Minio) MergeLifecycleRules(rules []LifecycleRule) error {
+	newRules, err := r.lifecycleRules(rules)
+	if err != nil {
+		return err
+	}
+
+	configuration, err := r.lifecycleConfiguration()
+	if err != nil {
+		return err
+	}
+
+	configuration.Rules = mergeLifecycleRules(configuration.Rules, newRules)
+
+	return convertError(r.instance.SetBucketLifecycle(r.ctx, r.bucket, configuration))
+}
+
+// ReconcileLifecycle merges the lifecycle rules of the disk configuration into the bucket, it does nothing
+// if the disk doesn't configure any lifecycle rules.
+func (r *Minio) ReconcileLifecycle() error {
+	rules, err := newLifecycleRules(r.config, r.disk)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return r.MergeLifecycleRules(rules)
+}
+
+// SetLifecycleRules replaces all the lifecycle rules of the disk, the rules outside the root of the disk
+// are kept, and the lifecycle configuration of the bucket is removed if no rules are left.
+func (r *Minio) SetLifecycleRules(rules []LifecycleRule) error {
+	newRules, err := r.lifecycleRules(rules)
+	if err != nil {
+		return err
+	}
+
+	configuration, err := r.lifecycleConfiguration()
+	if err != nil {
+		return err
+	}
+
+	var kept []lifecycle.Rule
+	for _, rule := range configuration.Rules {
+		if !r.ownsLifecycleRule(rule) {
+			kept = append(kept, rule)
+		}
+	}
+	configuration.Rules = append(kept, newRules...)
+
+	return convertError(r.instance.SetBucketLifecycle(r.ctx, r.bucket, configuration))
+}
+
+// lifecycleConfiguration gets the lifecycle configuration of the bucket, it's empty if it is not set.
+func (r *Minio) lifecycleConfiguration() (*lifecycle.Configuration, error) {
+	configuration, err := r.instance.GetBucketLifecycle(r.ctx, r.bucket)
+	if err != nil {
+		var errResponse minio.ErrorResponse
+		if errors.As(err, &errResponse) && errResponse.Code == "NoSuchLifecycleConfiguration" {
+			return lifecycle.NewConfiguration(), nil
+		}
+
+		return nil, convertError(err)
+	}
+
+	return configuration, nil
+}
+
+func (r *Minio) lifecycleRules(rules []LifecycleRule) ([]lifecycle.Rule, error) {
+	ids := make(map[string]bool, len(rules))
+	lifecycleRules := make([]lifecycle.Rule, len(rules))
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("the lifecycle rule %s is duplicated", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		status := "Enabled"
+		if rule.Disabled {
+			status = "Disabled"
+		}
+
+		lifecycleRules[i] = lifecycle.Rule{
+			ID:     r.root + rule.ID,
+			Status: status,
+			RuleFilter: lifecycle.Filter{
+				Prefix: r.key(rule.Prefix),
+			},
+			Expiration: lifecycle.Expiration{
+				Days: lifecycle.ExpirationDays(rule.ExpirationDays),
+			},
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{
+				NoncurrentDays: lifecycle.ExpirationDays(rule.NoncurrentVersionExpirationDays),
+			},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: lifecycle.ExpirationDays(rule.AbortIncompleteMultipartUploadDays),
+			},
+			Transition: lifecycle.Transition{
+				Days:         lifecycle.ExpirationDays(rule.TransitionDays),
+				StorageClass: rule.TransitionStorageClass,
+			},
+		}
+	}
+
+	return lifecycleRules, nil
+}
+
+// ownsLifecycleRule reports whether the rule is managed by the disk, both its ID and its prefix are
+// under the root of the disk.
+func (r *Minio) ownsLifecycleRule(rule lifecycle.Rule) bool {
+	return strings.HasPrefix(rule.ID, r.root) && strings.HasPrefix(lifecyclePrefix(rule), r.root)
+}
+
+func (r LifecycleRule) validate() error {
+	if r.ID == "" {
+		return errors.New("the id of the lifecycle rule is required")
+	}
+	if r.ExpirationDays < 0 || r.NoncurrentVersionExpirationDays < 0 || r.AbortIncompleteMultipartUploadDays < 0 || r.TransitionDays < 0 {
+		return fmt.Errorf("the days of the lifecycle rule %s should be greater than or equal to 0", r.ID)
+	}
+	if r.TransitionDays > 0 && r.TransitionStorageClass == "" {
+		return fmt.Errorf("the transition storage class of the lifecycle rule %s is required", r.ID)
+	}
+	if r.ExpirationDays == 0 && r.NoncurrentVersionExpirationDays == 0 && r.AbortIncompleteMultipartUploadDays == 0 && r.TransitionDays == 0 {
+		return fmt.Errorf("the lifecycle rule %s should have at least one action", r.ID)
+	}
+
+	return nil
+}
+
+// lifecyclePrefix gets the prefix of the rule, it may be set in the filter or, for legacy rules, in the rule.
+func lifecyclePrefix(rule lifecycle.Rule) string {
+	switch {
+	case rule.RuleFilter.Prefix != "":
+		return rule.RuleFilter.Prefix
+	case rule.RuleFilter.And.Prefix != "":
+		return rule.RuleFilter.And.Prefix
+	default:
+		return rule.Prefix
+	}
+}
+
+// mergeLifecycleRules replaces the existing rules with the new rules of the same ID in place and appends
+// the others, the existing rules are kept as they are, so the filters and actions unknown to LifecycleRule
+// are not lost.
+func mergeLifecycleRules(existing, rules []lifecycle.Rule) []lifecycle.Rule {
+	indexes := make(map[string]int, len(existing))
+	for i, rule := range existing {
+		indexes[rule.ID] = i
+	}
+
+	merged := append([]lifecycle.Rule(nil), existing...)
+	for _, rule := range rules {
+		if i, ok := indexes[rule.ID]; ok {
+			merged[i] = rule
+		} else {
+			merged = append(merged, rule)
+		}
+	}
+
+	return merged
+}
+
+// newLifecycleRules reads the lifecycle rules of the disk configuration, for example:
+//
+//	"lifecycle": map[string]any{
+//		"rules": []map[string]any{
+//			{"id": "expire-tmp", "prefix": "tmp/", "expiration_days": 1},
+//		},
+//	},
+func newLifecycleRules(config config.Config, disk string) ([]LifecycleRule, error) {
+	var configRules []map[string]any
+	switch value := config.Get(fmt.Sprintf("filesystems.disks.%s.lifecycle.rules", disk)).(type) {
+	case nil:
+		return nil, nil
+	case []map[string]any:
+		configRules = value
+	case []any:
+		for _, item := range value {
+			configRule, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("the lifecycle rules of %s disk should be a list of maps", disk)
+			}
+			configRules = append(configRules, configRule)
+		}
+	default:
+		return nil, fmt.Errorf("the lifecycle rules of %s disk should be a list of maps", disk)
+	}
+
+	rules := make([]LifecycleRule, len(configRules))
+	for i, configRule := range configRules {
+		var rule LifecycleRule
+		rule.ID, _ = configRule["id"].(string)
+		rule.Prefix, _ = configRule["prefix"].(string)
+		rule.Disabled, _ = configRule["disabled"].(bool)
+		rule.TransitionStorageClass, _ = configRule["transition_storage_class"].(string)
+
+		for key, days := range map[string]*int{
+			"expiration_days":                        &rule.ExpirationDays,
+			"noncurrent_version_expiration_days":     &rule.NoncurrentVersionExpirationDays,
+			"abort_incomplete_multipart_upload_days": &rule.AbortIncompleteMultipartUploadDays,
+			"transition_days":                        &rule.TransitionDays,
+		} {
+			value, err := lifecycleDays(configRule[key])
+			if err != nil {
+				return nil, fmt.Errorf("the %s of the lifecycle rule %d of %s disk is invalid: %w", key, i, disk, err)
+			}
+			*days = value
+		}
+
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid lifecycle rule of %s disk: %w", disk, err)
+		}
+		rules[i] = rule
+	}
+
+	return rules, nil
+}
+
+// lifecycleDays converts the days of the configuration, they may be strings if they are read from env.
+func lifecycleDays(value any) (int, error) {
+	switch value := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return value, nil
+	case int64:
+		return int(value), nil
+	case float64:
+		if value != float64(int(value)) {
+			return 0, fmt.Errorf("%v is not an integer", value)
+		}
+		return int(value), nil
+	case string:
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(value)
+	default:
+		return 0, fmt.Errorf("%v is not an integer", value)
+	}
+}
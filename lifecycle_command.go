@@ -0,0 +1,91 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+// lifecycleTimeout bounds the reconciliation of a disk, so an unreachable endpoint doesn't stall the boot.
+const lifecycleTimeout = 10 * time.Second
+
+// LifecycleCommand reconciles the lifecycle rules of the disk configuration into the bucket.
+type LifecycleCommand struct {
+	config config.Config
+}
+
+func NewLifecycleCommand(config config.Config) *LifecycleCommand {
+	return &LifecycleCommand{config: config}
+}
+
+// Signature The name and signature of the console command.
+func (r *LifecycleCommand) Signature() string {
+	return "minio:lifecycle"
+}
+
+// Description The console command description.
+func (r *LifecycleCommand) Description() string {
+	return "Reconcile the lifecycle rules of a minio disk configuration into its bucket"
+}
+
+// Extend The console command extend.
+func (r *LifecycleCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "minio",
+		Arguments: []command.Argument{
+			&command.ArgumentString{
+				Name:     "disk",
+				Usage:    "The disk to reconcile",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *LifecycleCommand) Handle(ctx console.Context) error {
+	disk := ctx.ArgumentString("disk")
+	if err := reconcileLifecycle(r.config, disk); err != nil {
+		return err
+	}
+
+	ctx.Info(fmt.Sprintf("The lifecycle rules of %s disk are reconciled", disk))
+
+	return nil
+}
+
+// reconcileLifecycleOnBoot reconciles the lifecycle rules of the disks that set lifecycle.reconcile_on_boot.
+func reconcileLifecycleOnBoot(config config.Config) []error {
+	disks, _ := config.Get("filesystems.disks").(map[string]any)
+
+	var errs []error
+	for disk := range disks {
+		if !config.GetBool(fmt.Sprintf("filesystems.disks.%s.lifecycle.reconcile_on_boot", disk), false) {
+			continue
+		}
+		if err := reconcileLifecycle(config, disk); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func reconcileLifecycle(config config.Config, disk string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycleTimeout)
+	defer cancel()
+
+	driver, err := NewMinio(ctx, config, disk)
+	if err != nil {
+		return err
+	}
+	if err := driver.ReconcileLifecycle(); err != nil {
+		return fmt.Errorf("reconcile the lifecycle rules of %s disk error: %w", disk, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package minio
+
+import (
+	"testing"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	consolemock "github.com/goravel/framework/mocks/console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLifecycleCommand_Handle(t *testing.T) {
+	mockConfig := configmock.NewConfig(t)
+	mockConfig.EXPECT().GetString(mock.Anything).Return("")
+	mockConfig.EXPECT().GetBool(mock.Anything, mock.Anything).Return(false)
+	mockContext := consolemock.NewContext(t)
+	mockContext.EXPECT().ArgumentString("disk").Return("minio").Once()
+
+	assert.EqualError(t, NewLifecycleCommand(mockConfig).Handle(mockContext), "please set minio configuration first")
+}
@@ -0,0 +1,142 @@
+package minio
+
+import (
+	"testing"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLifecycleRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     any
+		expected  []LifecycleRule
+		expectErr bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "rules",
+			rules: []map[string]any{
+				{"id": "expire-tmp", "prefix": "tmp/", "expiration_days": 1, "abort_incomplete_multipart_upload_days": int64(2)},
+				{"id": "archive", "disabled": true, "transition_days": "30", "transition_storage_class": "GLACIER", "noncurrent_version_expiration_days": float64(7)},
+			},
+			expected: []LifecycleRule{
+				{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 1, AbortIncompleteMultipartUploadDays: 2},
+				{ID: "archive", Disabled: true, TransitionDays: 30, TransitionStorageClass: "GLACIER", NoncurrentVersionExpirationDays: 7},
+			},
+		},
+		{
+			name:     "rules of any",
+			rules:    []any{map[string]any{"id": "expire-tmp", "expiration_days": 1}},
+			expected: []LifecycleRule{{ID: "expire-tmp", ExpirationDays: 1}},
+		},
+		{
+			name:      "not a list",
+			rules:     map[string]any{"id": "expire-tmp"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid days",
+			rules:     []map[string]any{{"id": "expire-tmp", "expiration_days": 1.5}},
+			expectErr: true,
+		},
+		{
+			name:      "without id",
+			rules:     []map[string]any{{"expiration_days": 1}},
+			expectErr: true,
+		},
+		{
+			name:      "without action",
+			rules:     []map[string]any{{"id": "expire-tmp"}},
+			expectErr: true,
+		},
+		{
+			name:      "transition without storage class",
+			rules:     []map[string]any{{"id": "archive", "transition_days": 30}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := configmock.NewConfig(t)
+			mockConfig.EXPECT().Get("filesystems.disks.minio.lifecycle.rules").Return(test.rules).Once()
+
+			rules, err := newLifecycleRules(mockConfig, "minio")
+			assert.Equal(t, test.expectErr, err != nil)
+			assert.Equal(t, test.expected, rules)
+		})
+	}
+}
+
+func TestLifecycleRules(t *testing.T) {
+	driver := &Minio{root: "root/"}
+
+	rules, err := driver.lifecycleRules([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 1, Disabled: true},
+		{ID: "archive", TransitionDays: 30, TransitionStorageClass: "GLACIER"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "root/expire-tmp", rules[0].ID)
+	assert.Equal(t, "Disabled", rules[0].Status)
+	assert.Equal(t, "root/tmp/", rules[0].RuleFilter.Prefix)
+	assert.Equal(t, lifecycle.ExpirationDays(1), rules[0].Expiration.Days)
+	assert.Equal(t, "root/archive", rules[1].ID)
+	assert.Equal(t, "Enabled", rules[1].Status)
+	assert.Equal(t, "root/", rules[1].RuleFilter.Prefix)
+	assert.Equal(t, lifecycle.ExpirationDays(30), rules[1].Transition.Days)
+	assert.Equal(t, "GLACIER", rules[1].Transition.StorageClass)
+
+	_, err = driver.lifecycleRules([]LifecycleRule{
+		{ID: "expire-tmp", ExpirationDays: 1},
+		{ID: "expire-tmp", ExpirationDays: 2},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestOwnsLifecycleRule(t *testing.T) {
+	rule := func(id, prefix string) lifecycle.Rule {
+		return lifecycle.Rule{ID: id, RuleFilter: lifecycle.Filter{Prefix: prefix}}
+	}
+
+	driver := &Minio{root: "a/"}
+	assert.True(t, driver.ownsLifecycleRule(rule("a/expire-tmp", "a/tmp/")))
+	assert.False(t, driver.ownsLifecycleRule(rule("b/expire-tmp", "b/tmp/")))
+	assert.False(t, driver.ownsLifecycleRule(rule("expire-tmp", "a/tmp/")))
+	assert.False(t, driver.ownsLifecycleRule(rule("a/expire-tmp", "")))
+
+	driver = &Minio{}
+	assert.True(t, driver.ownsLifecycleRule(rule("b/expire-tmp", "b/tmp/")))
+	assert.True(t, driver.ownsLifecycleRule(rule("manual", "")))
+}
+
+func TestLifecyclePrefix(t *testing.T) {
+	assert.Equal(t, "tmp/", lifecyclePrefix(lifecycle.Rule{RuleFilter: lifecycle.Filter{Prefix: "tmp/"}}))
+	assert.Equal(t, "tmp/", lifecyclePrefix(lifecycle.Rule{RuleFilter: lifecycle.Filter{And: lifecycle.And{Prefix: "tmp/"}}}))
+	assert.Equal(t, "tmp/", lifecyclePrefix(lifecycle.Rule{Prefix: "tmp/"}))
+	assert.Equal(t, "", lifecyclePrefix(lifecycle.Rule{}))
+}
+
+func TestMergeLifecycleRules(t *testing.T) {
+	existing := []lifecycle.Rule{
+		{ID: "expire-tmp", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 1}},
+		{ID: "manual", Status: "Enabled", RuleFilter: lifecycle.Filter{Tag: lifecycle.Tag{Key: "temporary", Value: "true"}}},
+	}
+
+	merged := mergeLifecycleRules(existing, []lifecycle.Rule{
+		{ID: "expire-tmp", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 7}},
+		{ID: "archive", Status: "Enabled", Transition: lifecycle.Transition{Days: 30, StorageClass: "GLACIER"}},
+	})
+
+	assert.Len(t, merged, 3)
+	assert.Equal(t, "expire-tmp", merged[0].ID)
+	assert.Equal(t, lifecycle.ExpirationDays(7), merged[0].Expiration.Days)
+	assert.Equal(t, existing[1], merged[1])
+	assert.Equal(t, "archive", merged[2].ID)
+	assert.Equal(t, lifecycle.ExpirationDays(1), existing[0].Expiration.Days)
+}
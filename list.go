@@ -0,0 +1,102 @@
+package minio
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// maxKeysPerRequest is the maximum number of keys returned by a single listing request of S3.
+const maxKeysPerRequest = 1000
+
+// ListEntry is a file or directory returned by List.
+type ListEntry struct {
+	ETag string
+	// IsDirectory is true for common prefixes and marker objects, their keys end with "/".
+	IsDirectory bool
+	// Key is relative to the listed path.
+	Key string
+	// LastModified is in the timezone of the application, it's zero for common prefixes.
+	LastModified time.Time
+	// Size is the stored size, for files encrypted on the client side it's the size of the encrypted content,
+	// since the listing doesn't include the metadata telling if a file is encrypted, use Size for the plaintext.
+	Size int64
+}
+
+// ListOptions configures List.
+type ListOptions struct {
+	// MaxKeys stops the listing after the number of entries, it's unlimited if it is 0.
+	MaxKeys int
+	// Recursive lists all the files under the path instead of the files and directories directly in it.
+	Recursive bool
+	// StartAfter starts the listing after the key relative to the path, pass the key of the last entry of the
+	// previous page to get the next page.
+	StartAfter string
+}
+
+// List iterates over the entries under the path, the objects are fetched page by page while iterating,
+// so huge prefixes can be listed without loading all keys into memory.
+func (r *Minio) List(path string, options ListOptions) iter.Seq2[ListEntry, error] {
+	return func(yield func(ListEntry, error) bool) {
+		l, err := time.LoadLocation(r.timezone)
+		if err != nil {
+			yield(ListEntry{}, err)
+			return
+		}
+
+		// Cancel the listing if the iteration is stopped, otherwise the goroutine of minio-go leaks.
+		ctx, cancel := context.WithCancel(r.ctx)
+		defer cancel()
+
+		prefix := r.root + validPath(path)
+		listObjectsOptions := minio.ListObjectsOptions{
+			Prefix:    prefix,
+			Recursive: options.Recursive,
+		}
+		if options.StartAfter != "" {
+			listObjectsOptions.StartAfter = prefix + options.StartAfter
+		}
+		if options.MaxKeys > 0 && options.MaxKeys < maxKeysPerRequest {
+			listObjectsOptions.MaxKeys = options.MaxKeys
+		}
+
+		count := 0
+		for object := range r.instance.ListObjects(ctx, r.bucket, listObjectsOptions) {
+			if object.Err != nil {
+				yield(ListEntry{}, convertError(object.Err))
+				return
+			}
+
+			key := strings.TrimPrefix(object.Key, prefix)
+			if key == "" {
+				continue
+			}
+			// S3 rolls the keys after StartAfter up into their common prefix, so a directory passed as
+			// StartAfter is returned again by a listing that isn't recursive.
+			if options.StartAfter != "" && key <= options.StartAfter {
+				continue
+			}
+
+			entry := ListEntry{
+				ETag:        object.ETag,
+				IsDirectory: strings.HasSuffix(key, "/"),
+				Key:         key,
+				Size:        object.Size,
+			}
+			if !object.LastModified.IsZero() {
+				entry.LastModified = object.LastModified.In(l)
+			}
+			if !yield(entry, nil) {
+				return
+			}
+
+			count++
+			if options.MaxKeys > 0 && count >= options.MaxKeys {
+				return
+			}
+		}
+	}
+}
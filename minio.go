@@ -2,22 +2,21 @@ package minio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"net/url"
+	"maps"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/gabriel-vasile/mimetype"
 	"github.com/goravel/framework/contracts/config"
 	"github.com/goravel/framework/contracts/filesystem"
 	"github.com/goravel/framework/http"
-	"github.com/goravel/framework/support/carbon"
-	"github.com/goravel/framework/support/color"
 	"github.com/goravel/framework/support/str"
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 )
 
 /*
@@ -26,99 +25,132 @@ import (
  * Example: https://github.com/minio/minio-go/tree/master/examples/s3
  */
 
+const (
+	// mimetypeReadLimit is the number of bytes used by mimetype to detect the content type.
+	mimetypeReadLimit = 3072
+	// streamPartSize is the default part size of the uploads of unknown size, minio allocates a buffer of the
+	// part size for every upload, its own default is about 537 MiB in order to reach the maximum object size,
+	// so the size of such uploads is limited to about 156 GiB unless WithPartSize is set.
+	streamPartSize = 16 << 20
+)
+
 type Minio struct {
 	ctx      context.Context
 	config   config.Config
 	instance *minio.Client
 	bucket   string
 	disk     string
+	root     string
 	url      string
 	timezone string
+	// directoryMarkers determines if zero-byte "folder/" objects are created for the parent directories of
+	// written files, directories are implicit on S3, so they can be disabled to save requests.
+	directoryMarkers bool
+	// encryption is the server-side encryption applied to every object, it's nil if it is not configured.
+	encryption encrypt.ServerSide
+	// clientEncryption encrypts the content before uploading it, it's nil if it is not configured.
+	clientEncryption *clientEncryption
+	// acl determines if the visibility of single files is set by object ACLs, they are not supported by MinIO.
+	acl bool
 }
 
 func NewMinio(ctx context.Context, config config.Config, disk string) (*Minio, error) {
-	key := config.GetString(fmt.Sprintf("filesystems.disks.%s.key", disk))
-	secret := config.GetString(fmt.Sprintf("filesystems.disks.%s.secret", disk))
 	region := config.GetString(fmt.Sprintf("filesystems.disks.%s.region", disk))
 	bucket := config.GetString(fmt.Sprintf("filesystems.disks.%s.bucket", disk))
 	diskUrl := config.GetString(fmt.Sprintf("filesystems.disks.%s.url", disk))
 	ssl := config.GetBool(fmt.Sprintf("filesystems.disks.%s.ssl", disk), false)
 	endpoint := config.GetString(fmt.Sprintf("filesystems.disks.%s.endpoint", disk))
+	root := config.GetString(fmt.Sprintf("filesystems.disks.%s.root", disk))
+	directoryMarkers := config.GetBool(fmt.Sprintf("filesystems.disks.%s.directory_markers", disk), true)
+	acl := config.GetBool(fmt.Sprintf("filesystems.disks.%s.acl", disk), false)
 	timezone := config.GetString("app.timezone")
-	if key == "" || secret == "" || bucket == "" || diskUrl == "" || endpoint == "" {
+	if bucket == "" || diskUrl == "" || endpoint == "" {
 		return nil, fmt.Errorf("please set %s configuration first", disk)
 	}
 
+	creds, err := newCredentials(config, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := newTransport(config, disk, ssl)
+	if err != nil {
+		return nil, err
+	}
+
+	encryption, err := newEncryption(config, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	clientEncryption, err := newClientEncryption(config, disk)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoint = strings.TrimPrefix(endpoint, "http://")
 	endpoint = strings.TrimPrefix(endpoint, "https://")
 
 	client, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(key, secret, ""),
-		Secure: ssl,
-		Region: region,
+		Creds:     creds,
+		Secure:    ssl,
+		Region:    region,
+		Transport: transport,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("init %s disk error: %s", disk, err)
 	}
 
 	return &Minio{
-		ctx:      ctx,
-		config:   config,
-		instance: client,
-		bucket:   bucket,
-		disk:     disk,
-		url:      diskUrl,
-		timezone: timezone,
+		ctx:              ctx,
+		config:           config,
+		instance:         client,
+		bucket:           bucket,
+		disk:             disk,
+		root:             validPath(root),
+		url:              diskUrl,
+		timezone:         timezone,
+		directoryMarkers: directoryMarkers,
+		encryption:       encryption,
+		clientEncryption: clientEncryption,
+		acl:              acl,
 	}, nil
 }
 
+// AllDirectories gets all the directories within the given directory with a single recursive listing,
+// the directories are derived from the keys, so directories without marker objects are included as well.
 func (r *Minio) AllDirectories(path string) ([]string, error) {
+	seen := make(map[string]bool)
 	var directories []string
-	validPath := validPath(path)
-	objectCh := r.instance.ListObjects(r.ctx, r.bucket, minio.ListObjectsOptions{
-		Prefix:    validPath,
-		Recursive: false,
-	})
-
-	for object := range objectCh {
-		if object.Err != nil {
-			return nil, object.Err
+	for entry, err := range r.List(path, ListOptions{Recursive: true}) {
+		if err != nil {
+			return nil, err
 		}
 
-		if strings.HasSuffix(object.Key, "/") {
-			key := strings.TrimPrefix(object.Key, validPath)
-			if key != "" {
-				directories = append(directories, key)
-				subDirectories, err := r.AllDirectories(object.Key)
-				if err != nil {
-					return nil, err
-				}
-				for _, subDirectory := range subDirectories {
-					directories = append(directories, strings.TrimPrefix(object.Key+subDirectory, validPath))
-				}
+		for i := range len(entry.Key) {
+			if entry.Key[i] != '/' {
+				continue
+			}
+			if directory := entry.Key[:i+1]; !seen[directory] {
+				seen[directory] = true
+				directories = append(directories, directory)
 			}
 		}
 	}
 
+	sort.Strings(directories)
+
 	return directories, nil
 }
 
 func (r *Minio) AllFiles(path string) ([]string, error) {
 	var files []string
-	validPath := validPath(path)
-
-	objectCh := r.instance.ListObjects(r.ctx, r.bucket, minio.ListObjectsOptions{
-		Prefix:    validPath,
-		Recursive: true,
-	})
-
-	for object := range objectCh {
-		if object.Err != nil {
-			return nil, object.Err
+	for entry, err := range r.List(path, ListOptions{Recursive: true}) {
+		if err != nil {
+			return nil, err
 		}
-
-		if !strings.HasSuffix(object.Key, "/") {
-			files = append(files, strings.TrimPrefix(object.Key, validPath))
+		if !entry.IsDirectory {
+			files = append(files, entry.Key)
 		}
 	}
 
@@ -126,68 +158,29 @@ func (r *Minio) AllFiles(path string) ([]string, error) {
 }
 
 func (r *Minio) Copy(originFile, targetFile string) error {
-	srcOpts := minio.CopySrcOptions{
-		Bucket: r.bucket,
-		Object: originFile,
-	}
-	dstOpts := minio.CopyDestOptions{
-		Bucket: r.bucket,
-		Object: targetFile,
-	}
-	_, err := r.instance.CopyObject(r.ctx, dstOpts, srcOpts)
-	return err
+	return r.CopyTo(originFile, r, targetFile)
 }
 
 func (r *Minio) Delete(files ...string) error {
-	objectsCh := make(chan minio.ObjectInfo, len(files))
-	go func() {
-		defer close(objectsCh)
-		for _, file := range files {
-			object := minio.ObjectInfo{
-				Key: file,
-			}
-			objectsCh <- object
-		}
-	}()
-
-	for err := range r.instance.RemoveObjects(r.ctx, r.bucket, objectsCh, minio.RemoveObjectsOptions{}) {
-		return err.Err
-	}
-
-	return nil
+	return r.DeleteWithOptions(files, DeleteOptions{})
 }
 
 func (r *Minio) DeleteDirectory(directory string) error {
-	if !strings.HasSuffix(directory, "/") {
-		directory += "/"
-	}
-	opts := minio.RemoveObjectOptions{
-		ForceDelete: true,
-	}
-	err := r.instance.RemoveObject(r.ctx, r.bucket, directory, opts)
-	if err != nil {
-		return err
-	}
+	_, err := r.DeleteDirectoryWithOptions(directory, DeleteDirectoryOptions{})
 
-	return nil
+	return err
 }
 
+// Directories gets the directories within the given directory, they are the common prefixes of the
+// non-recursive listing, so directories without marker objects are included as well.
 func (r *Minio) Directories(path string) ([]string, error) {
 	var directories []string
-	validPath := validPath(path)
-	objectCh := r.instance.ListObjects(r.ctx, r.bucket, minio.ListObjectsOptions{
-		Prefix:    validPath,
-		Recursive: false,
-	})
-	for object := range objectCh {
-		if object.Err != nil {
-			return nil, object.Err
+	for entry, err := range r.List(path, ListOptions{}) {
+		if err != nil {
+			return nil, err
 		}
-		if strings.HasSuffix(object.Key, "/") {
-			directory := strings.ReplaceAll(object.Key, validPath, "")
-			if directory != "" {
-				directories = append(directories, directory)
-			}
+		if entry.IsDirectory {
+			directories = append(directories, entry.Key)
 		}
 	}
 
@@ -195,24 +188,41 @@ func (r *Minio) Directories(path string) ([]string, error) {
 }
 
 func (r *Minio) Exists(file string) bool {
-	_, err := r.instance.StatObject(r.ctx, r.bucket, file, minio.StatObjectOptions{})
+	_, err := r.stat(file)
 
 	return err == nil
 }
 
+// ExistsE determines if a file exists, unlike Exists, it returns false without an error only if the file is
+// not found, other failures such as a network outage are returned.
+func (r *Minio) ExistsE(file string) (bool, error) {
+	if _, err := r.stat(file); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			// S3 responds to a HEAD request of a missing bucket with a bodyless 404 as well, which is translated
+			// to NoSuchKey by minio, so the bucket is checked to distinguish them. The check requires the
+			// s3:ListBucket permission, credentials scoped to a prefix may lack it, so a failed check is
+			// treated as the file not being found.
+			if exists, err := r.instance.BucketExists(r.ctx, r.bucket); err == nil && !exists {
+				return false, ErrBucketMissing
+			}
+
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *Minio) Files(path string) ([]string, error) {
 	var files []string
-	validPath := validPath(path)
-
-	for object := range r.instance.ListObjects(r.ctx, r.bucket, minio.ListObjectsOptions{
-		Prefix:    validPath,
-		Recursive: false,
-	}) {
-		if object.Err != nil {
-			return nil, object.Err
+	for entry, err := range r.List(path, ListOptions{}) {
+		if err != nil {
+			return nil, err
 		}
-		if !strings.HasSuffix(object.Key, "/") {
-			files = append(files, strings.ReplaceAll(object.Key, validPath, ""))
+		if !entry.IsDirectory {
+			files = append(files, entry.Key)
 		}
 	}
 
@@ -226,7 +236,7 @@ func (r *Minio) Get(file string) (string, error) {
 }
 
 func (r *Minio) GetBytes(file string) ([]byte, error) {
-	object, err := r.instance.GetObject(r.ctx, r.bucket, file, minio.GetObjectOptions{})
+	object, err := r.ReadStream(file)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +251,7 @@ func (r *Minio) GetBytes(file string) ([]byte, error) {
 }
 
 func (r *Minio) LastModified(file string) (time.Time, error) {
-	objInfo, err := r.instance.StatObject(r.ctx, r.bucket, file, minio.StatObjectOptions{})
+	info, err := r.stat(file)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -251,7 +261,7 @@ func (r *Minio) LastModified(file string) (time.Time, error) {
 		return time.Time{}, err
 	}
 
-	return objInfo.LastModified.In(l), nil
+	return info.LastModified.In(l), nil
 }
 
 func (r *Minio) MakeDirectory(directory string) error {
@@ -259,12 +269,12 @@ func (r *Minio) MakeDirectory(directory string) error {
 }
 
 func (r *Minio) MimeType(file string) (string, error) {
-	objInfo, err := r.instance.StatObject(r.ctx, r.bucket, file, minio.StatObjectOptions{})
+	info, err := r.stat(file)
 	if err != nil {
 		return "", err
 	}
 
-	return objInfo.ContentType, nil
+	return info.ContentType, nil
 }
 
 func (r *Minio) Missing(file string) bool {
@@ -279,14 +289,67 @@ func (r *Minio) Move(oldFile, newFile string) error {
 	return r.Delete(oldFile)
 }
 
+// Path gets the full key of the file in the bucket, including the root of the disk.
 func (r *Minio) Path(file string) string {
-	return file
+	return r.key(file)
 }
 
 func (r *Minio) Put(file string, content string) error {
+	return r.PutWithOptions(file, content)
+}
+
+func (r *Minio) PutFile(filePath string, source filesystem.File) (string, error) {
+	return r.PutFileWithOptions(filePath, source)
+}
+
+func (r *Minio) PutFileAs(filePath string, source filesystem.File, name string) (string, error) {
+	return r.PutFileAsWithOptions(filePath, source, name)
+}
+
+// PutFileAsWithOptions uploads the given file with a new name and the given options.
+func (r *Minio) PutFileAsWithOptions(filePath string, source filesystem.File, name string, options ...PutOption) (string, error) {
+	fullPath, err := fullPathOfFile(filePath, source, name)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := os.Open(source.File())
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	info, err := reader.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.PutStream(fullPath, reader, info.Size(), options...); err != nil {
+		return "", err
+	}
+
+	return fullPath, nil
+}
+
+// PutFileWithOptions uploads the given file with the given options.
+func (r *Minio) PutFileWithOptions(filePath string, source filesystem.File, options ...PutOption) (string, error) {
+	return r.PutFileAsWithOptions(filePath, source, str.Random(40), options...)
+}
+
+// PutStream writes the contents of the reader to the file without buffering it in memory,
+// the size can be -1 if it is unknown, then the content will be uploaded via multipart with a buffer of
+// 16 MiB by default, use WithPartSize to upload larger content.
+func (r *Minio) PutStream(file string, reader io.Reader, size int64, options ...PutOption) error {
+	opts := newPutOptions(options)
+	if err := r.putVisibility(file, opts); err != nil {
+		return err
+	}
+
 	// If the file is created in a folder directly, we can't check if the folder exists.
 	// So we need to create the folders first.
-	if !strings.HasSuffix(file, "/") {
+	if r.directoryMarkers && !strings.HasSuffix(file, "/") {
 		folders := strings.Split(file, "/")
 		for i := 1; i < len(folders); i++ {
 			folder := strings.Join(folders[:i], "/")
@@ -296,62 +359,142 @@ func (r *Minio) Put(file string, content string) error {
 		}
 	}
 
-	mtype := mimetype.Detect([]byte(content))
-	reader := strings.NewReader(content)
-	_, err := r.instance.PutObject(
-		r.ctx,
-		r.bucket,
-		file,
-		reader,
-		reader.Size(),
-		minio.PutObjectOptions{
-			ContentType: mtype.String(),
-		},
-	)
+	putObjectOptions := minio.PutObjectOptions{
+		ContentType:          opts.contentType,
+		CacheControl:         opts.cacheControl,
+		ContentDisposition:   opts.contentDisposition,
+		ContentEncoding:      opts.contentEncoding,
+		UserMetadata:         opts.metadata,
+		UserTags:             opts.tags,
+		ServerSideEncryption: r.encryption,
+	}
+	if putObjectOptions.ContentType == "" {
+		contentType, contentReader, err := detectContentType(reader)
+		if err != nil {
+			return err
+		}
+		putObjectOptions.ContentType = contentType
+		reader = contentReader
+	}
 
-	return err
+	if opts.acl != "" {
+		putObjectOptions.UserMetadata = maps.Clone(putObjectOptions.UserMetadata)
+		if putObjectOptions.UserMetadata == nil {
+			putObjectOptions.UserMetadata = make(map[string]string, 1)
+		}
+		// minio-go sends the x-amz-acl header as it is instead of as user metadata.
+		putObjectOptions.UserMetadata["X-Amz-Acl"] = opts.acl
+	}
+
+	if r.clientEncryption != nil && !strings.HasSuffix(file, "/") {
+		encryptedReader, encryptedSize, metadata, err := r.clientEncryption.encrypt(reader, size)
+		if err != nil {
+			return err
+		}
+		reader = encryptedReader
+		size = encryptedSize
+		putObjectOptions.UserMetadata = maps.Clone(putObjectOptions.UserMetadata)
+		if putObjectOptions.UserMetadata == nil {
+			putObjectOptions.UserMetadata = make(map[string]string, len(metadata))
+		}
+		maps.Copy(putObjectOptions.UserMetadata, metadata)
+	}
+
+	putObjectOptions.PartSize = opts.partSize
+	if size < 0 && putObjectOptions.PartSize == 0 {
+		putObjectOptions.PartSize = streamPartSize
+	}
+
+	_, err := r.instance.PutObject(r.ctx, r.bucket, r.key(file), reader, size, putObjectOptions)
+
+	return convertError(err)
 }
 
-func (r *Minio) PutFile(filePath string, source filesystem.File) (string, error) {
-	return r.PutFileAs(filePath, source, str.Random(40))
+// PutWithOptions writes the contents of a file with the given options.
+func (r *Minio) PutWithOptions(file, content string, options ...PutOption) error {
+	return r.PutStream(file, strings.NewReader(content), int64(len(content)), options...)
 }
 
-func (r *Minio) PutFileAs(filePath string, source filesystem.File, name string) (string, error) {
-	fullPath, err := fullPathOfFile(filePath, source, name)
-	if err != nil {
-		return "", err
+// ReadRange gets a reader of the file content starting at the offset, a length <= 0 means reading until the end.
+// The reader must be closed by the caller.
+func (r *Minio) ReadRange(file string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid offset %d, it should be greater than or equal to 0", offset)
 	}
 
-	data, err := os.ReadFile(source.File())
-	if err != nil {
-		return "", err
+	opts := minio.GetObjectOptions{}
+	if length > 0 {
+		if err := opts.SetRange(offset, offset+length-1); err != nil {
+			return nil, err
+		}
+	} else if offset > 0 {
+		if err := opts.SetRange(offset, 0); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := r.Put(fullPath, string(data)); err != nil {
-		return "", err
+	if r.clientEncryption != nil {
+		return r.readDecryptedRange(file, opts, offset, length)
 	}
 
-	return fullPath, nil
+	return r.getObject(file, opts)
+}
+
+// ReadStream gets a reader of the file content without loading it into memory.
+// The reader must be closed by the caller.
+func (r *Minio) ReadStream(file string) (io.ReadCloser, error) {
+	return r.getObject(file, minio.GetObjectOptions{})
 }
 
 func (r *Minio) Size(file string) (int64, error) {
-	objInfo, err := r.instance.StatObject(r.ctx, r.bucket, file, minio.StatObjectOptions{})
+	info, err := r.stat(file)
 	if err != nil {
 		return 0, err
 	}
 
-	return objInfo.Size, nil
+	return info.Size, nil
 }
 
-func (r *Minio) TemporaryUrl(file string, time time.Time) (string, error) {
-	file = strings.TrimPrefix(file, "/")
-	reqParams := make(url.Values)
-	presignedURL, err := r.instance.PresignedGetObject(r.ctx, r.bucket, file, time.Sub(carbon.Now().StdTime()), reqParams)
+// Stat gets all the metadata of the file with a single request, plus another one to get the tags if
+// the file has any. Prefer it to calling Size, MimeType and LastModified separately.
+func (r *Minio) Stat(file string) (*ObjectAttributes, error) {
+	info, err := r.stat(file)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	l, err := time.LoadLocation(r.timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := newObjectAttributes(info, l)
+	if info.UserTagCount > 0 {
+		objectTags, err := r.instance.GetObjectTagging(r.ctx, r.bucket, r.key(file), minio.GetObjectTaggingOptions{
+			VersionID: info.VersionID,
+		})
+		if err != nil {
+			return nil, convertError(err)
+		}
+		attributes.Tags = objectTags.ToMap()
 	}
 
-	return presignedURL.String(), nil
+	return attributes, nil
+}
+
+func (r *Minio) TemporaryUrl(file string, time time.Time) (string, error) {
+	return r.TemporaryUrlWithOptions(file, time, TemporaryUrlOptions{})
+}
+
+// WithBucket gets a copy of the disk for another bucket of the same server, it shares the client and
+// options of the disk. The bucket is removed from the end of the url, virtual-hosted style urls should
+// be replaced by the Url of a dedicated disk.
+func (r *Minio) WithBucket(bucket string) *Minio {
+	driver := *r
+	driver.bucket = bucket
+	driver.url = strings.TrimSuffix(strings.TrimSuffix(r.url, "/"), "/"+r.bucket)
+
+	return &driver
 }
 
 func (r *Minio) WithContext(ctx context.Context) filesystem.Driver {
@@ -359,14 +502,11 @@ func (r *Minio) WithContext(ctx context.Context) filesystem.Driver {
 		ctx = httpCtx.Context()
 	}
 
-	driver, err := NewMinio(ctx, r.config, r.disk)
-	if err != nil {
-		color.Red().Printfln("init %s disk fail: %v\n", r.disk, err)
-
-		return nil
-	}
+	// Share the client with the copy, building a new one for every request causes connection churn.
+	driver := *r
+	driver.ctx = ctx
 
-	return driver
+	return &driver
 }
 
 func (r *Minio) Url(file string) string {
@@ -375,5 +515,65 @@ func (r *Minio) Url(file string) string {
 		realUrl += "/" + r.bucket
 	}
 
-	return realUrl + "/" + strings.TrimPrefix(file, "/")
+	return realUrl + "/" + strings.TrimPrefix(r.key(file), "/")
+}
+
+func (r *Minio) getObject(file string, opts minio.GetObjectOptions) (io.ReadCloser, error) {
+	opts.ServerSideEncryption = r.readEncryption()
+	object, err := r.instance.GetObject(r.ctx, r.bucket, r.key(file), opts)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	// GetObject is lazy, stat the object to send the request, then errors such as a missing file are returned here.
+	info, err := object.Stat()
+	if err != nil {
+		_ = object.Close()
+
+		return nil, convertError(err)
+	}
+
+	if isClientEncrypted(info.UserMetadata) {
+		if r.clientEncryption == nil {
+			_ = object.Close()
+
+			return nil, fmt.Errorf("the file %s is encrypted on the client side, but the client encryption is not enabled", file)
+		}
+
+		decrypted, err := r.clientEncryption.decrypt(object, info.UserMetadata)
+		if err != nil {
+			_ = object.Close()
+
+			return nil, err
+		}
+
+		return &readCloser{Reader: decrypted, Closer: object}, nil
+	}
+
+	return object, nil
+}
+
+func (r *Minio) stat(file string) (minio.ObjectInfo, error) {
+	info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+		ServerSideEncryption: r.readEncryption(),
+	})
+	if err != nil {
+		return minio.ObjectInfo{}, convertError(err)
+	}
+
+	if isClientEncrypted(info.UserMetadata) {
+		info.Size = decryptedSize(info.Size)
+		info.UserMetadata = withoutEncryptionMetadata(info.UserMetadata)
+	}
+
+	return info, nil
+}
+
+// key gets the key of the file in the bucket, the root of the disk is prepended if it is set.
+func (r *Minio) key(file string) string {
+	if r.root == "" {
+		return file
+	}
+
+	return r.root + strings.TrimPrefix(file, "/")
 }
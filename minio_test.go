@@ -1,12 +1,16 @@
 package minio
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -120,13 +124,14 @@ func (s *MinioTestSuite) SetupSuite() {
 
 	s.docker = docker
 	s.minio = &Minio{
-		config:   s.mockConfig,
-		ctx:      context.Background(),
-		instance: client,
-		bucket:   testBucket,
-		disk:     "minio",
-		url:      fmt.Sprintf("http://%s/%s", endpoint, testBucket),
-		timezone: "UTC",
+		config:           s.mockConfig,
+		ctx:              context.Background(),
+		instance:         client,
+		bucket:           testBucket,
+		disk:             "minio",
+		url:              fmt.Sprintf("http://%s/%s", endpoint, testBucket),
+		timezone:         "UTC",
+		directoryMarkers: true,
 	}
 }
 
@@ -168,6 +173,24 @@ func (s *MinioTestSuite) TestAllDirectories() {
 	s.Nil(s.minio.DeleteDirectory("AllDirectories"))
 }
 
+func (s *MinioTestSuite) TestAllDirectories_Implicit() {
+	driver := *s.minio
+	driver.directoryMarkers = false
+
+	s.Nil(s.minio.Put("AllDirectoriesImplicit/1/1.txt", "Goravel"))
+	s.Nil(driver.Put("AllDirectoriesImplicit/1/2/3/3.txt", "Goravel"))
+	s.Nil(driver.Put("AllDirectoriesImplicit/4/4.txt", "Goravel"))
+	s.Nil(s.minio.MakeDirectory("AllDirectoriesImplicit/5"))
+	s.True(s.minio.Missing("AllDirectoriesImplicit/1/2/"))
+
+	directories, err := s.minio.AllDirectories("AllDirectoriesImplicit")
+	s.Nil(err)
+	s.Equal([]string{"1/", "1/2/", "1/2/3/", "4/", "5/"}, directories)
+
+	s.Nil(s.minio.Delete("AllDirectoriesImplicit/1/2/3/3.txt", "AllDirectoriesImplicit/4/4.txt"))
+	s.Nil(s.minio.DeleteDirectory("AllDirectoriesImplicit"))
+}
+
 func (s *MinioTestSuite) TestAllFiles() {
 	s.Nil(s.minio.Put("AllFiles/1.txt", "Goravel"))
 	s.Nil(s.minio.Put("AllFiles/2.txt", "Goravel"))
@@ -192,6 +215,94 @@ func (s *MinioTestSuite) TestAllFiles() {
 	s.Nil(s.minio.DeleteDirectory("AllFiles"))
 }
 
+func (s *MinioTestSuite) TestClientEncryption() {
+	keys := map[string][]byte{"1": bytes.Repeat([]byte("a"), 32)}
+	driver, err := s.minio.WithClientEncryption(keys, "1")
+	s.Nil(err)
+
+	content := strings.Repeat("Goravel", clientEncryptionChunkSize/7+1)
+	s.Nil(driver.Put("ClientEncryption/1.txt", content))
+	s.True(s.minio.Exists("ClientEncryption/"))
+
+	data, err := driver.Get("ClientEncryption/1.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+	_, err = s.minio.Get("ClientEncryption/1.txt")
+	s.NotNil(err)
+	raw, err := s.minio.instance.GetObject(context.Background(), testBucket, "ClientEncryption/1.txt", minio.GetObjectOptions{})
+	s.Nil(err)
+	encrypted, err := io.ReadAll(raw)
+	s.Nil(err)
+	s.NotContains(string(encrypted), "Goravel")
+
+	size, err := driver.Size("ClientEncryption/1.txt")
+	s.Nil(err)
+	s.Equal(int64(len(content)), size)
+	mimeType, err := driver.MimeType("ClientEncryption/1.txt")
+	s.Nil(err)
+	s.True(strings.HasPrefix(mimeType, "text/plain"))
+	attributes, err := driver.Stat("ClientEncryption/1.txt")
+	s.Nil(err)
+	s.Empty(attributes.Metadata)
+
+	reader, err := driver.ReadRange("ClientEncryption/1.txt", int64(len(content)-7), 3)
+	s.Nil(err)
+	part, err := io.ReadAll(reader)
+	s.Nil(err)
+	s.Nil(reader.Close())
+	s.Equal("Gor", string(part))
+
+	for _, test := range []struct{ offset, length int64 }{
+		{offset: 0, length: 3},
+		{offset: clientEncryptionChunkSize - 3, length: 10},
+		{offset: clientEncryptionChunkSize + 1, length: 0},
+		{offset: int64(len(content)), length: 0},
+	} {
+		reader, err := driver.ReadRange("ClientEncryption/1.txt", test.offset, test.length)
+		s.Nil(err)
+		part, err := io.ReadAll(reader)
+		s.Nil(err)
+		s.Nil(reader.Close())
+		end := int64(len(content))
+		if test.length > 0 {
+			end = test.offset + test.length
+		}
+		s.Equal(content[test.offset:end], string(part))
+	}
+
+	s.Nil(driver.Copy("ClientEncryption/1.txt", "ClientEncryption/2.txt"))
+	data, err = driver.Get("ClientEncryption/2.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+
+	s.Nil(driver.CopyTo("ClientEncryption/1.txt", s.minio, "ClientEncryption/3.txt"))
+	data, err = s.minio.Get("ClientEncryption/3.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+
+	keys["2"] = bytes.Repeat([]byte("b"), 32)
+	rotated, err := s.minio.WithClientEncryption(keys, "2")
+	s.Nil(err)
+	count, err := rotated.RotateEncryptionKeys("ClientEncryption")
+	s.Nil(err)
+	s.Equal(2, count)
+	count, err = rotated.RotateEncryptionKeys("ClientEncryption")
+	s.Nil(err)
+	s.Equal(0, count)
+
+	delete(keys, "1")
+	onlyNew, err := s.minio.WithClientEncryption(keys, "2")
+	s.Nil(err)
+	data, err = onlyNew.Get("ClientEncryption/1.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+	data, err = onlyNew.Get("ClientEncryption/3.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+
+	s.Nil(s.minio.DeleteDirectory("ClientEncryption"))
+}
+
 func (s *MinioTestSuite) TestCopy() {
 	s.Nil(s.minio.Put("Copy/1.txt", "Goravel"))
 	s.True(s.minio.Exists("Copy/1.txt"))
@@ -202,6 +313,74 @@ func (s *MinioTestSuite) TestCopy() {
 	s.Nil(s.minio.DeleteDirectory("Copy1"))
 }
 
+func (s *MinioTestSuite) TestCopyTo() {
+	s.Nil(s.minio.instance.MakeBucket(context.Background(), "archive", minio.MakeBucketOptions{}))
+	archive := s.minio.WithBucket("archive")
+
+	s.Nil(s.minio.PutWithOptions("CopyTo/1.txt", "Goravel", WithCacheControl("no-cache"), WithMetadata(map[string]string{"Author": "goravel"})))
+	s.Nil(s.minio.CopyTo("CopyTo/1.txt", archive, "CopyTo/2.txt"))
+	s.True(s.minio.Exists("CopyTo/1.txt"))
+	data, err := archive.Get("CopyTo/2.txt")
+	s.Nil(err)
+	s.Equal("Goravel", data)
+
+	// Another endpoint of the same server streams the file.
+	client, err := minio.New(strings.Replace(s.minio.instance.EndpointURL().Host, "127.0.0.1", "localhost", 1), &minio.Options{
+		Creds: credentials.NewStaticV4(testKey, testSecret, ""),
+	})
+	s.Nil(err)
+	another := *archive
+	another.instance = client
+	s.False(s.minio.sameServer(&another))
+
+	s.Nil(s.minio.MoveTo("CopyTo/1.txt", &another, "CopyTo/3.txt"))
+	s.True(s.minio.Missing("CopyTo/1.txt"))
+	attributes, err := archive.Stat("CopyTo/3.txt")
+	s.Nil(err)
+	s.Equal(int64(7), attributes.Size)
+	s.Equal("no-cache", attributes.CacheControl)
+	s.Equal(map[string]string{"Author": "goravel"}, attributes.Metadata)
+
+	s.NotNil(s.minio.CopyTo("CopyTo/4.txt", archive, "CopyTo/4.txt"))
+
+	s.Nil(archive.DeleteDirectory("CopyTo"))
+	s.Nil(s.minio.DeleteDirectory("CopyTo"))
+}
+
+func (s *MinioTestSuite) TestCopyWithOptions() {
+	s.Nil(s.minio.PutWithOptions("CopyWithOptions/1.txt", "Goravel",
+		WithCacheControl("no-cache"),
+		WithMetadata(map[string]string{"Author": "goravel"}),
+		WithTags(map[string]string{"env": "test"}),
+	))
+
+	s.Nil(s.minio.Copy("CopyWithOptions/1.txt", "CopyWithOptions/2.txt"))
+	attributes, err := s.minio.Stat("CopyWithOptions/2.txt")
+	s.Nil(err)
+	s.Equal("no-cache", attributes.CacheControl)
+	s.Equal(map[string]string{"Author": "goravel"}, attributes.Metadata)
+	s.Equal(map[string]string{"env": "test"}, attributes.Tags)
+
+	s.Nil(s.minio.CopyWithOptions("CopyWithOptions/1.txt", "CopyWithOptions/3.txt",
+		WithContentType("application/octet-stream"),
+		WithMetadata(map[string]string{"Editor": "goravel"}),
+	))
+	attributes, err = s.minio.Stat("CopyWithOptions/3.txt")
+	s.Nil(err)
+	s.Equal("application/octet-stream", attributes.ContentType)
+	s.Equal("no-cache", attributes.CacheControl)
+	s.Equal(map[string]string{"Editor": "goravel"}, attributes.Metadata)
+	s.Equal(map[string]string{"env": "test"}, attributes.Tags)
+
+	s.Nil(s.minio.CopyWithOptions("CopyWithOptions/1.txt", "CopyWithOptions/4.txt", WithTags(map[string]string{"env": "prod"})))
+	attributes, err = s.minio.Stat("CopyWithOptions/4.txt")
+	s.Nil(err)
+	s.Equal(map[string]string{"Author": "goravel"}, attributes.Metadata)
+	s.Equal(map[string]string{"env": "prod"}, attributes.Tags)
+
+	s.Nil(s.minio.DeleteDirectory("CopyWithOptions"))
+}
+
 func (s *MinioTestSuite) TestDelete() {
 	s.Nil(s.minio.Put("Delete/1.txt", "Goravel"))
 	s.True(s.minio.Exists("Delete/1.txt"))
@@ -218,6 +397,72 @@ func (s *MinioTestSuite) TestDeleteDirectory() {
 	s.Nil(s.minio.DeleteDirectory("DeleteDirectory"))
 }
 
+func (s *MinioTestSuite) TestDeleteDirectoryWithOptions() {
+	s.Nil(s.minio.Put("DeleteDirectoryWithOptions/1.txt", "Goravel"))
+	s.Nil(s.minio.Put("DeleteDirectoryWithOptions/a/b/2.txt", "Goravel"))
+	s.Nil(s.minio.Put("DeleteDirectoryWithOptions1/3.txt", "Goravel"))
+
+	result, err := s.minio.DeleteDirectoryWithOptions("DeleteDirectoryWithOptions", DeleteDirectoryOptions{DryRun: true})
+	s.Nil(err)
+	s.Equal(&DeleteDirectoryResult{Deleted: 5}, result)
+	s.True(s.minio.Exists("DeleteDirectoryWithOptions/1.txt"))
+
+	result, err = s.minio.DeleteDirectoryWithOptions("DeleteDirectoryWithOptions", DeleteDirectoryOptions{})
+	s.Nil(err)
+	s.Equal(&DeleteDirectoryResult{Deleted: 5}, result)
+	s.True(s.minio.Missing("DeleteDirectoryWithOptions/1.txt"))
+	s.True(s.minio.Missing("DeleteDirectoryWithOptions/a/b/2.txt"))
+	s.True(s.minio.Exists("DeleteDirectoryWithOptions1/3.txt"))
+
+	_, err = s.minio.DeleteDirectoryWithOptions("/", DeleteDirectoryOptions{})
+	s.NotNil(err)
+
+	bucket := "delete-directory"
+	s.Nil(s.minio.instance.MakeBucket(context.Background(), bucket, minio.MakeBucketOptions{}))
+	s.Nil(s.minio.instance.EnableVersioning(context.Background(), bucket))
+	driver := *s.minio
+	driver.bucket = bucket
+	driver.directoryMarkers = false
+
+	s.Nil(driver.Put("DeleteDirectoryWithOptions/1.txt", "Goravel"))
+	s.Nil(driver.Put("DeleteDirectoryWithOptions/1.txt", "Goravel Framework"))
+	s.Nil(driver.DeleteDirectory("DeleteDirectoryWithOptions"))
+	s.True(driver.Missing("DeleteDirectoryWithOptions/1.txt"))
+
+	result, err = driver.DeleteDirectoryWithOptions("DeleteDirectoryWithOptions", DeleteDirectoryOptions{Versions: true})
+	s.Nil(err)
+	s.Equal(&DeleteDirectoryResult{Deleted: 3}, result)
+	result, err = driver.DeleteDirectoryWithOptions("DeleteDirectoryWithOptions", DeleteDirectoryOptions{DryRun: true, Versions: true})
+	s.Nil(err)
+	s.Equal(&DeleteDirectoryResult{}, result)
+
+	s.Nil(s.minio.DeleteDirectory("DeleteDirectoryWithOptions1"))
+}
+
+func (s *MinioTestSuite) TestDeleteWithOptions() {
+	s.Nil(s.minio.Put("DeleteWithOptions/1.txt", "Goravel"))
+	s.Nil(s.minio.Put("DeleteWithOptions/2.txt", "Goravel"))
+
+	s.Nil(s.minio.DeleteWithOptions([]string{"DeleteWithOptions/1.txt", "DeleteWithOptions/3.txt"}, DeleteOptions{}))
+	s.True(s.minio.Missing("DeleteWithOptions/1.txt"))
+
+	err := s.minio.DeleteWithOptions([]string{"DeleteWithOptions/2.txt", "DeleteWithOptions/3.txt", "DeleteWithOptions/4.txt"}, DeleteOptions{Strict: true})
+	s.ErrorIs(err, ErrNotFound)
+	var deleteErr *DeleteError
+	s.True(errors.As(err, &deleteErr))
+	s.Len(deleteErr.Errors, 2)
+	s.Equal("DeleteWithOptions/3.txt", deleteErr.Errors[0].Key)
+	s.Equal("DeleteWithOptions/4.txt", deleteErr.Errors[1].Key)
+	s.True(s.minio.Missing("DeleteWithOptions/2.txt"))
+
+	driver := *s.minio
+	driver.bucket = "missing-bucket"
+	err = driver.Delete("DeleteWithOptions/1.txt", "DeleteWithOptions/2.txt")
+	s.ErrorIs(err, ErrBucketMissing)
+
+	s.Nil(s.minio.DeleteDirectory("DeleteWithOptions"))
+}
+
 func (s *MinioTestSuite) TestDirectories() {
 	s.Nil(s.minio.Put("Directories/1.txt", "Goravel"))
 	s.Nil(s.minio.Put("Directories/2.txt", "Goravel"))
@@ -244,6 +489,28 @@ func (s *MinioTestSuite) TestDirectories() {
 	s.Nil(s.minio.DeleteDirectory("Directories"))
 }
 
+func (s *MinioTestSuite) TestExistsE() {
+	s.Nil(s.minio.Put("ExistsE/1.txt", "Goravel"))
+	exists, err := s.minio.ExistsE("ExistsE/1.txt")
+	s.Nil(err)
+	s.True(exists)
+
+	exists, err = s.minio.ExistsE("ExistsE/2.txt")
+	s.Nil(err)
+	s.False(exists)
+
+	_, err = s.minio.Get("ExistsE/2.txt")
+	s.ErrorIs(err, ErrNotFound)
+
+	driver := *s.minio
+	driver.bucket = "missing-bucket"
+	exists, err = driver.ExistsE("ExistsE/1.txt")
+	s.ErrorIs(err, ErrBucketMissing)
+	s.False(exists)
+
+	s.Nil(s.minio.DeleteDirectory("ExistsE"))
+}
+
 func (s *MinioTestSuite) TestFiles() {
 	s.Nil(s.minio.Put("Files/1.txt", "Goravel"))
 	s.Nil(s.minio.Put("Files/2.txt", "Goravel"))
@@ -304,6 +571,131 @@ func (s *MinioTestSuite) TestLastModified() {
 	s.Nil(s.minio.DeleteDirectory("LastModified"))
 }
 
+func (s *MinioTestSuite) TestLifecycle() {
+	s.Nil(s.minio.instance.MakeBucket(context.Background(), "lifecycle", minio.MakeBucketOptions{}))
+	driver := s.minio.WithBucket("lifecycle")
+	driver.root = "root/"
+
+	rules, err := driver.LifecycleRules()
+	s.Nil(err)
+	s.Empty(rules)
+
+	s.NotNil(driver.SetLifecycleRules([]LifecycleRule{{ID: "expire-tmp"}}))
+	s.Nil(driver.SetLifecycleRules([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 1},
+		{ID: "abort-uploads", AbortIncompleteMultipartUploadDays: 2},
+	}))
+	rules, err = driver.LifecycleRules()
+	s.Nil(err)
+	s.Equal([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 1},
+		{ID: "abort-uploads", AbortIncompleteMultipartUploadDays: 2},
+	}, rules)
+
+	s.mockConfig.EXPECT().Get("filesystems.disks.minio.lifecycle.rules").Return([]map[string]any{
+		{"id": "expire-tmp", "prefix": "tmp/", "expiration_days": 7},
+		{"id": "expire-versions", "noncurrent_version_expiration_days": 30, "disabled": true},
+	}).Once()
+	s.Nil(driver.ReconcileLifecycle())
+	rules, err = driver.LifecycleRules()
+	s.Nil(err)
+	s.Equal([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 7},
+		{ID: "abort-uploads", AbortIncompleteMultipartUploadDays: 2},
+		{ID: "expire-versions", NoncurrentVersionExpirationDays: 30, Disabled: true},
+	}, rules)
+
+	another := s.minio.WithBucket("lifecycle")
+	another.root = "another/"
+	s.Nil(another.SetLifecycleRules([]LifecycleRule{{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 3}}))
+	s.Nil(another.MergeLifecycleRules([]LifecycleRule{{ID: "expire-versions", NoncurrentVersionExpirationDays: 5}}))
+	rules, err = another.LifecycleRules()
+	s.Nil(err)
+	s.Equal([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 3},
+		{ID: "expire-versions", NoncurrentVersionExpirationDays: 5},
+	}, rules)
+	rules, err = driver.LifecycleRules()
+	s.Nil(err)
+	s.Equal([]LifecycleRule{
+		{ID: "expire-tmp", Prefix: "tmp/", ExpirationDays: 7},
+		{ID: "abort-uploads", AbortIncompleteMultipartUploadDays: 2},
+		{ID: "expire-versions", NoncurrentVersionExpirationDays: 30, Disabled: true},
+	}, rules)
+
+	s.Nil(driver.SetLifecycleRules(nil))
+	rules, err = driver.LifecycleRules()
+	s.Nil(err)
+	s.Empty(rules)
+	rules, err = another.LifecycleRules()
+	s.Nil(err)
+	s.Len(rules, 2)
+
+	s.Nil(another.SetLifecycleRules(nil))
+	configuration, err := another.lifecycleConfiguration()
+	s.Nil(err)
+	s.Empty(configuration.Rules)
+}
+
+func (s *MinioTestSuite) TestList() {
+	s.Nil(s.minio.Put("List/1.txt", "Goravel"))
+	s.Nil(s.minio.Put("List/2.txt", "Goravel"))
+	s.Nil(s.minio.Put("List/3/3.txt", "Goravel"))
+	s.Nil(s.minio.Put("List/4.txt", "Goravel"))
+
+	var keys []string
+	for entry, err := range s.minio.List("List", ListOptions{}) {
+		s.Nil(err)
+		keys = append(keys, entry.Key)
+		if entry.Key == "3/" {
+			s.True(entry.IsDirectory)
+		} else {
+			s.False(entry.IsDirectory)
+			s.Equal(int64(7), entry.Size)
+			s.NotEmpty(entry.ETag)
+			s.Equal("UTC", entry.LastModified.Location().String())
+		}
+	}
+	s.Equal([]string{"1.txt", "2.txt", "3/", "4.txt"}, keys)
+
+	list := func(options ListOptions) []string {
+		var keys []string
+		for entry, err := range s.minio.List("/List/", options) {
+			s.Nil(err)
+			keys = append(keys, entry.Key)
+		}
+
+		return keys
+	}
+	s.Equal([]string{"1.txt", "2.txt", "3/", "3/3.txt", "4.txt"}, list(ListOptions{Recursive: true}))
+	s.Equal([]string{"1.txt", "2.txt"}, list(ListOptions{Recursive: true, MaxKeys: 2}))
+	s.Equal([]string{"3/", "3/3.txt"}, list(ListOptions{Recursive: true, MaxKeys: 2, StartAfter: "2.txt"}))
+	s.Equal([]string{"4.txt"}, list(ListOptions{Recursive: true, MaxKeys: 2, StartAfter: "3/3.txt"}))
+	s.Equal([]string{"2.txt", "3/"}, list(ListOptions{MaxKeys: 2, StartAfter: "1.txt"}))
+	s.Equal([]string{"4.txt"}, list(ListOptions{MaxKeys: 1, StartAfter: "3/"}))
+
+	var pages []string
+	startAfter := ""
+	for {
+		page := list(ListOptions{MaxKeys: 1, StartAfter: startAfter})
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page...)
+		startAfter = page[len(page)-1]
+	}
+	s.Equal([]string{"1.txt", "2.txt", "3/", "4.txt"}, pages)
+
+	count := 0
+	for range s.minio.List("List", ListOptions{Recursive: true}) {
+		count++
+		break
+	}
+	s.Equal(1, count)
+
+	s.Nil(s.minio.DeleteDirectory("List"))
+}
+
 func (s *MinioTestSuite) TestMakeDirectory() {
 	s.Nil(s.minio.MakeDirectory("MakeDirectory1/"))
 	s.Nil(s.minio.MakeDirectory("MakeDirectory2"))
@@ -342,6 +734,55 @@ func (s *MinioTestSuite) TestMove() {
 	s.Nil(s.minio.DeleteDirectory("Move1"))
 }
 
+func (s *MinioTestSuite) TestObjectLock() {
+	driver := s.minio.WithBucket("object-lock")
+	driver.directoryMarkers = false
+	s.Nil(driver.MakeBucket(MakeBucketOptions{ObjectLock: true}))
+
+	retention, err := driver.DefaultRetention()
+	s.Nil(err)
+	s.Nil(retention)
+	s.NotNil(driver.SetDefaultRetention(DefaultRetention{Mode: RetentionGovernance}))
+	s.Nil(driver.SetDefaultRetention(DefaultRetention{Mode: RetentionGovernance, Days: 1}))
+	retention, err = driver.DefaultRetention()
+	s.Nil(err)
+	s.Equal(&DefaultRetention{Mode: RetentionGovernance, Days: 1}, retention)
+	s.Nil(driver.RemoveDefaultRetention())
+
+	s.Nil(driver.Put("ObjectLock/1.txt", "Goravel"))
+	objectRetention, err := driver.Retention("ObjectLock/1.txt")
+	s.Nil(err)
+	s.Nil(objectRetention)
+
+	retainUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	s.Nil(driver.SetRetention("ObjectLock/1.txt", Retention{Mode: RetentionGovernance, RetainUntil: retainUntil}, false))
+	objectRetention, err = driver.Retention("ObjectLock/1.txt")
+	s.Nil(err)
+	s.Equal(RetentionGovernance, objectRetention.Mode)
+	s.True(retainUntil.Equal(objectRetention.RetainUntil))
+
+	versions, err := driver.Versions("ObjectLock/1.txt")
+	s.Nil(err)
+	s.ErrorIs(driver.DeleteVersion("ObjectLock/1.txt", versions[0].VersionID), ErrObjectLocked)
+
+	s.Nil(driver.Put("ObjectLock/2.txt", "Goravel"))
+	s.Nil(driver.SetLegalHold("ObjectLock/2.txt", true))
+	hold, err := driver.LegalHold("ObjectLock/2.txt")
+	s.Nil(err)
+	s.True(hold)
+	_, err = driver.DeleteDirectoryWithOptions("ObjectLock", DeleteDirectoryOptions{Versions: true, BypassGovernance: true})
+	s.ErrorIs(err, ErrObjectLocked)
+	s.True(driver.Exists("ObjectLock/2.txt"))
+	s.True(driver.Missing("ObjectLock/1.txt"))
+
+	s.Nil(driver.SetLegalHold("ObjectLock/2.txt", false))
+	hold, err = driver.LegalHold("ObjectLock/2.txt")
+	s.Nil(err)
+	s.False(hold)
+	_, err = driver.DeleteDirectoryWithOptions("ObjectLock", DeleteDirectoryOptions{Versions: true})
+	s.Nil(err)
+}
+
 func (s *MinioTestSuite) TestPut() {
 	s.Nil(s.minio.Put("Put/a/b/1.txt", "Goravel"))
 	s.True(s.minio.Exists("Put/"))
@@ -352,6 +793,103 @@ func (s *MinioTestSuite) TestPut() {
 	s.Nil(s.minio.DeleteDirectory("Put"))
 }
 
+func (s *MinioTestSuite) TestPresignedPostPolicy() {
+	post, err := s.minio.PresignedPostPolicy().
+		Expires(time.Now().Add(time.Minute)).
+		KeyStartsWith("PresignedPostPolicy/").
+		ContentTypeStartsWith("text/").
+		ContentLengthRange(1, 10).
+		SuccessActionStatus(201).
+		Presign()
+	s.Nil(err)
+	s.NotEmpty(post.Url)
+
+	upload := func(key, content string) int {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for field, value := range post.Fields {
+			if field == "key" {
+				value = key
+			}
+			if field == "Content-Type" {
+				value = "text/plain"
+			}
+			s.Nil(writer.WriteField(field, value))
+		}
+		part, err := writer.CreateFormFile("file", "1.txt")
+		s.Nil(err)
+		_, err = part.Write([]byte(content))
+		s.Nil(err)
+		s.Nil(writer.Close())
+
+		resp, err := http.Post(post.Url, writer.FormDataContentType(), body)
+		s.Nil(err)
+		s.Nil(resp.Body.Close())
+
+		return resp.StatusCode
+	}
+
+	s.Equal(http.StatusCreated, upload("PresignedPostPolicy/1.txt", "Goravel"))
+	data, err := s.minio.Get("PresignedPostPolicy/1.txt")
+	s.Nil(err)
+	s.Equal("Goravel", data)
+
+	s.NotEqual(http.StatusCreated, upload("Others/1.txt", "Goravel"))
+	s.NotEqual(http.StatusCreated, upload("PresignedPostPolicy/2.txt", "Goravel Framework"))
+
+	_, err = s.minio.PresignedPostPolicy().Key("PresignedPostPolicy/1.txt").Presign()
+	s.NotNil(err)
+
+	s.Nil(s.minio.Delete("PresignedPostPolicy/1.txt"))
+}
+
+func (s *MinioTestSuite) TestPutStream() {
+	s.Nil(s.minio.PutStream("PutStream/a/1.txt", strings.NewReader("Goravel"), 7))
+	s.True(s.minio.Exists("PutStream/a/"))
+	s.True(s.minio.Exists("PutStream/a/1.txt"))
+	data, err := s.minio.Get("PutStream/a/1.txt")
+	s.Nil(err)
+	s.Equal("Goravel", data)
+
+	// Unknown size
+	content := strings.Repeat("Goravel", 1024)
+	s.Nil(s.minio.PutStream("PutStream/2.txt", io.MultiReader(strings.NewReader(content)), -1))
+	data, err = s.minio.Get("PutStream/2.txt")
+	s.Nil(err)
+	s.Equal(content, data)
+	mimeType, err := s.minio.MimeType("PutStream/2.txt")
+	s.Nil(err)
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	s.Nil(err)
+	s.Equal("text/plain", mediaType)
+
+	s.Nil(s.minio.DeleteDirectory("PutStream"))
+}
+
+func (s *MinioTestSuite) TestPut_WithoutDirectoryMarkers() {
+	driver := *s.minio
+	driver.directoryMarkers = false
+
+	s.Nil(driver.Put("PutWithoutMarkers/a/b/1.txt", "Goravel"))
+	s.Nil(driver.Put("PutWithoutMarkers/a/2.txt", "Goravel"))
+	s.True(driver.Exists("PutWithoutMarkers/a/b/1.txt"))
+	s.True(driver.Missing("PutWithoutMarkers/"))
+	s.True(driver.Missing("PutWithoutMarkers/a/"))
+	s.True(driver.Missing("PutWithoutMarkers/a/b/"))
+
+	directories, err := driver.Directories("PutWithoutMarkers")
+	s.Nil(err)
+	s.Equal([]string{"a/"}, directories)
+	directories, err = driver.AllDirectories("PutWithoutMarkers")
+	s.Nil(err)
+	s.Equal([]string{"a/", "a/b/"}, directories)
+	files, err := driver.Files("PutWithoutMarkers/a")
+	s.Nil(err)
+	s.Equal([]string{"2.txt"}, files)
+
+	s.Nil(driver.Delete("PutWithoutMarkers/a/b/1.txt", "PutWithoutMarkers/a/2.txt"))
+}
+
 func (s *MinioTestSuite) TestPutFile_Image() {
 	fileInfo := &File{path: "logo.png"}
 	path, err := s.minio.PutFile("PutFile1", fileInfo)
@@ -408,6 +946,139 @@ func (s *MinioTestSuite) TestPutFileAs_Image() {
 	s.Nil(s.minio.DeleteDirectory("PutFileAs1"))
 }
 
+func (s *MinioTestSuite) TestPutWithOptions() {
+	s.Nil(s.minio.PutWithOptions("PutWithOptions/1.txt", "Goravel",
+		WithContentType("application/octet-stream"),
+		WithCacheControl("max-age=3600"),
+		WithContentDisposition("attachment; filename=goravel.txt"),
+		WithMetadata(map[string]string{"Author": "goravel"}),
+		WithTags(map[string]string{"env": "test"}),
+	))
+
+	info, err := s.minio.instance.StatObject(context.Background(), testBucket, "PutWithOptions/1.txt", minio.StatObjectOptions{})
+	s.Nil(err)
+	s.Equal("application/octet-stream", info.ContentType)
+	s.Equal("max-age=3600", info.Metadata.Get("Cache-Control"))
+	s.Equal("attachment; filename=goravel.txt", info.Metadata.Get("Content-Disposition"))
+	s.Equal("goravel", info.UserMetadata["Author"])
+
+	objectTags, err := s.minio.instance.GetObjectTagging(context.Background(), testBucket, "PutWithOptions/1.txt", minio.GetObjectTaggingOptions{})
+	s.Nil(err)
+	s.Equal(map[string]string{"env": "test"}, objectTags.ToMap())
+
+	path, err := s.minio.PutFileAsWithOptions("PutWithOptions", &File{path: "test.txt"}, "text", WithCacheControl("no-cache"))
+	s.Nil(err)
+	s.Equal("PutWithOptions/text.txt", path)
+	info, err = s.minio.instance.StatObject(context.Background(), testBucket, path, minio.StatObjectOptions{})
+	s.Nil(err)
+	s.Equal("no-cache", info.Metadata.Get("Cache-Control"))
+
+	path, err = s.minio.PutFileWithOptions("PutWithOptions", &File{path: "logo.png"}, WithMetadata(map[string]string{"Author": "goravel"}))
+	s.Nil(err)
+	info, err = s.minio.instance.StatObject(context.Background(), testBucket, path, minio.StatObjectOptions{})
+	s.Nil(err)
+	s.Equal("image/png", info.ContentType)
+	s.Equal("goravel", info.UserMetadata["Author"])
+
+	s.Nil(s.minio.DeleteDirectory("PutWithOptions"))
+}
+
+func (s *MinioTestSuite) TestReadRange() {
+	s.Nil(s.minio.Put("ReadRange/1.txt", "Goravel"))
+
+	reader, err := s.minio.ReadRange("ReadRange/1.txt", 2, 3)
+	s.Nil(err)
+	content, err := io.ReadAll(reader)
+	s.Nil(err)
+	s.Nil(reader.Close())
+	s.Equal("rav", string(content))
+
+	reader, err = s.minio.ReadRange("ReadRange/1.txt", 4, 0)
+	s.Nil(err)
+	content, err = io.ReadAll(reader)
+	s.Nil(err)
+	s.Nil(reader.Close())
+	s.Equal("vel", string(content))
+
+	_, err = s.minio.ReadRange("ReadRange/1.txt", -1, 0)
+	s.NotNil(err)
+
+	s.Nil(s.minio.DeleteDirectory("ReadRange"))
+}
+
+func (s *MinioTestSuite) TestReadStream() {
+	s.Nil(s.minio.Put("ReadStream/1.txt", "Goravel"))
+
+	reader, err := s.minio.ReadStream("ReadStream/1.txt")
+	s.Nil(err)
+	content, err := io.ReadAll(reader)
+	s.Nil(err)
+	s.Nil(reader.Close())
+	s.Equal("Goravel", string(content))
+
+	reader, err = s.minio.ReadStream("ReadStream/2.txt")
+	s.NotNil(err)
+	s.Nil(reader)
+
+	s.Nil(s.minio.DeleteDirectory("ReadStream"))
+}
+
+func (s *MinioTestSuite) TestRoot() {
+	driver := *s.minio
+	driver.root = validPath("/Root/tenant")
+
+	s.Equal("Root/tenant/a/1.txt", driver.Path("a/1.txt"))
+	s.Equal("Root/tenant/a/1.txt", driver.Path("/a/1.txt"))
+	s.Equal(s.minio.url+"/Root/tenant/a/1.txt", driver.Url("a/1.txt"))
+
+	s.Nil(driver.Put("a/1.txt", "Goravel"))
+	s.Nil(driver.Put("a/b/2.txt", "Goravel"))
+	s.True(s.minio.Exists("Root/tenant/a/1.txt"))
+	s.True(driver.Exists("a/1.txt"))
+
+	files, err := driver.Files("a")
+	s.Nil(err)
+	s.Equal([]string{"1.txt"}, files)
+	files, err = driver.AllFiles("/")
+	s.Nil(err)
+	s.Equal([]string{"a/1.txt", "a/b/2.txt"}, files)
+	directories, err := driver.Directories("")
+	s.Nil(err)
+	s.Equal([]string{"a/"}, directories)
+	directories, err = driver.AllDirectories("")
+	s.Nil(err)
+	s.Equal([]string{"a/", "a/b/"}, directories)
+
+	data, err := driver.Get("a/1.txt")
+	s.Nil(err)
+	s.Equal("Goravel", data)
+
+	url, err := driver.TemporaryUrl("a/1.txt", time.Now().Add(5*time.Second))
+	s.Nil(err)
+	resp, err := http.Get(url)
+	s.Nil(err)
+	content, err := io.ReadAll(resp.Body)
+	s.Nil(resp.Body.Close())
+	s.Nil(err)
+	s.Equal("Goravel", string(content))
+
+	s.Nil(driver.Copy("a/1.txt", "c/1.txt"))
+	s.True(s.minio.Exists("Root/tenant/c/1.txt"))
+	s.Nil(driver.Move("c/1.txt", "c/2.txt"))
+	s.True(driver.Missing("c/1.txt"))
+	s.True(driver.Exists("c/2.txt"))
+	s.Nil(driver.Delete("c/2.txt"))
+	s.True(driver.Missing("c/2.txt"))
+
+	s.NotNil(driver.DeleteDirectory(""))
+	s.NotNil(driver.DeleteDirectory("/"))
+	s.True(driver.Exists("a/1.txt"))
+
+	s.Nil(driver.DeleteDirectory("a"))
+	s.Nil(driver.DeleteDirectory("c"))
+	s.Nil(s.minio.DeleteDirectory("Root"))
+}
+
 func (s *MinioTestSuite) TestSize() {
 	s.Nil(s.minio.Put("Size/1.txt", "Goravel"))
 	s.True(s.minio.Exists("Size/1.txt"))
@@ -417,6 +1088,36 @@ func (s *MinioTestSuite) TestSize() {
 	s.Nil(s.minio.DeleteDirectory("Size"))
 }
 
+func (s *MinioTestSuite) TestStat() {
+	s.Nil(s.minio.PutWithOptions("Stat/1.txt", "Goravel",
+		WithCacheControl("max-age=3600"),
+		WithMetadata(map[string]string{"Author": "goravel"}),
+		WithTags(map[string]string{"env": "test"}),
+	))
+
+	attributes, err := s.minio.Stat("Stat/1.txt")
+	s.Nil(err)
+	s.Equal(int64(7), attributes.Size)
+	mediaType, _, err := mime.ParseMediaType(attributes.ContentType)
+	s.Nil(err)
+	s.Equal("text/plain", mediaType)
+	s.NotEmpty(attributes.ETag)
+	s.Equal("UTC", attributes.LastModified.Location().String())
+	s.Equal("max-age=3600", attributes.CacheControl)
+	s.Equal(map[string]string{"Author": "goravel"}, attributes.Metadata)
+	s.Equal(map[string]string{"env": "test"}, attributes.Tags)
+
+	s.Nil(s.minio.Put("Stat/2.txt", "Goravel"))
+	attributes, err = s.minio.Stat("Stat/2.txt")
+	s.Nil(err)
+	s.Empty(attributes.Tags)
+
+	_, err = s.minio.Stat("Stat/3.txt")
+	s.ErrorIs(err, ErrNotFound)
+
+	s.Nil(s.minio.DeleteDirectory("Stat"))
+}
+
 func (s *MinioTestSuite) TestTemporaryUrl() {
 	s.Nil(s.minio.Put("TemporaryUrl/1.txt", "Goravel"))
 	s.True(s.minio.Exists("TemporaryUrl/1.txt"))
@@ -432,6 +1133,82 @@ func (s *MinioTestSuite) TestTemporaryUrl() {
 	s.Nil(s.minio.DeleteDirectory("TemporaryUrl"))
 }
 
+func (s *MinioTestSuite) TestTemporaryUploadUrl() {
+	request, err := s.minio.TemporaryUploadUrl("TemporaryUploadUrl/1.txt", time.Now().Add(5*time.Second), TemporaryUploadOptions{
+		ContentLength: 7,
+		ContentType:   "text/plain",
+		Metadata:      map[string]string{"Author": "goravel"},
+	})
+	s.Nil(err)
+	s.Equal(http.MethodPut, request.Method)
+	s.Equal(map[string]string{
+		"Content-Length":    "7",
+		"Content-Type":      "text/plain",
+		"X-Amz-Meta-Author": "goravel",
+	}, request.Headers)
+
+	upload := func(content, contentType string) int {
+		req, err := http.NewRequest(request.Method, request.Url, strings.NewReader(content))
+		s.Nil(err)
+		for key, value := range request.Headers {
+			req.Header.Set(key, value)
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		s.Nil(err)
+		s.Nil(resp.Body.Close())
+
+		return resp.StatusCode
+	}
+
+	s.NotEqual(http.StatusOK, upload("Goravel", "image/png"))
+	s.True(s.minio.Missing("TemporaryUploadUrl/1.txt"))
+
+	s.Equal(http.StatusOK, upload("Goravel", "text/plain"))
+	attributes, err := s.minio.Stat("TemporaryUploadUrl/1.txt")
+	s.Nil(err)
+	s.Equal("text/plain", attributes.ContentType)
+	s.Equal("goravel", attributes.Metadata["Author"])
+
+	_, err = s.minio.TemporaryUploadUrl("TemporaryUploadUrl/2.txt", time.Now().Add(5*time.Second), TemporaryUploadOptions{ContentLength: -1})
+	s.NotNil(err)
+
+	s.Nil(s.minio.Delete("TemporaryUploadUrl/1.txt"))
+}
+
+func (s *MinioTestSuite) TestTemporaryUrlWithOptions() {
+	s.Nil(s.minio.Put("TemporaryUrlWithOptions/1.txt", "Goravel"))
+
+	url, err := s.minio.TemporaryUrlWithOptions("TemporaryUrlWithOptions/1.txt", time.Now().Add(5*time.Second), TemporaryUrlOptions{
+		CacheControl: "no-cache",
+		ContentType:  "application/octet-stream",
+		DownloadName: "goravel.txt",
+	})
+	s.Nil(err)
+	resp, err := http.Get(url)
+	s.Nil(err)
+	content, err := io.ReadAll(resp.Body)
+	s.Nil(resp.Body.Close())
+	s.Nil(err)
+	s.Equal("Goravel", string(content))
+	s.Equal("no-cache", resp.Header.Get("Cache-Control"))
+	s.Equal("application/octet-stream", resp.Header.Get("Content-Type"))
+	s.Equal("attachment; filename=goravel.txt", resp.Header.Get("Content-Disposition"))
+
+	url, err = s.minio.TemporaryUrlFor("TemporaryUrlWithOptions/1.txt", 5*time.Second, TemporaryUrlOptions{
+		ContentDisposition: "inline",
+		DownloadName:       "goravel.txt",
+	})
+	s.Nil(err)
+	resp, err = http.Get(url)
+	s.Nil(err)
+	s.Nil(resp.Body.Close())
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("inline", resp.Header.Get("Content-Disposition"))
+
+	s.Nil(s.minio.DeleteDirectory("TemporaryUrlWithOptions"))
+}
+
 func (s *MinioTestSuite) TestUrl() {
 	s.Nil(s.minio.Put("Url/1.txt", "Goravel"))
 	s.True(s.minio.Exists("Url/1.txt"))
@@ -446,6 +1223,142 @@ func (s *MinioTestSuite) TestUrl() {
 	s.Nil(s.minio.DeleteDirectory("Url"))
 }
 
+func (s *MinioTestSuite) TestVersioning() {
+	s.Nil(s.minio.instance.MakeBucket(context.Background(), "versioning", minio.MakeBucketOptions{}))
+	driver := s.minio.WithBucket("versioning")
+
+	status, err := driver.VersioningStatus()
+	s.Nil(err)
+	s.Empty(status)
+	s.Nil(driver.EnableVersioning())
+	status, err = driver.VersioningStatus()
+	s.Nil(err)
+	s.Equal(VersioningEnabled, status)
+
+	s.Nil(driver.PutWithOptions("Versioning/1.txt", "Goravel", WithCacheControl("no-cache"), WithContentType("text/markdown"), WithTags(map[string]string{"version": "1"})))
+	s.Nil(driver.Put("Versioning/1.txt", "Goravel Framework"))
+	s.Nil(driver.Put("Versioning/1.txt.bak", "Goravel"))
+	s.Nil(driver.Delete("Versioning/1.txt"))
+	s.True(driver.Missing("Versioning/1.txt"))
+
+	versions, err := driver.Versions("Versioning/1.txt")
+	s.Nil(err)
+	s.Len(versions, 3)
+	s.True(versions[0].IsDeleteMarker)
+	s.True(versions[0].IsLatest)
+	s.False(versions[1].IsDeleteMarker)
+	s.Equal(int64(17), versions[1].Size)
+	s.Equal(int64(7), versions[2].Size)
+	s.Equal("UTC", versions[2].LastModified.Location().String())
+
+	data, err := driver.GetVersion("Versioning/1.txt", versions[2].VersionID)
+	s.Nil(err)
+	s.Equal("Goravel", data)
+
+	s.Nil(driver.RestoreVersion("Versioning/1.txt", versions[2].VersionID))
+	data, err = driver.Get("Versioning/1.txt")
+	s.Nil(err)
+	s.Equal("Goravel", data)
+	attributes, err := driver.Stat("Versioning/1.txt")
+	s.Nil(err)
+	s.Equal("no-cache", attributes.CacheControl)
+	s.Equal("text/markdown", attributes.ContentType)
+	s.Equal(map[string]string{"version": "1"}, attributes.Tags)
+	versions, err = driver.Versions("Versioning/1.txt")
+	s.Nil(err)
+	s.Len(versions, 4)
+
+	s.Nil(driver.DeleteVersion("Versioning/1.txt", versions[0].VersionID))
+	s.True(driver.Missing("Versioning/1.txt"))
+	s.True(versions[1].IsDeleteMarker)
+	s.Nil(driver.DeleteVersion("Versioning/1.txt", versions[1].VersionID))
+	data, err = driver.Get("Versioning/1.txt")
+	s.Nil(err)
+	s.Equal("Goravel Framework", data)
+
+	_, err = driver.GetVersion("Versioning/1.txt", versions[0].VersionID)
+	s.ErrorIs(err, ErrNotFound)
+
+	s.Nil(driver.SuspendVersioning())
+	status, err = driver.VersioningStatus()
+	s.Nil(err)
+	s.Equal(VersioningSuspended, status)
+
+	_, err = driver.DeleteDirectoryWithOptions("Versioning", DeleteDirectoryOptions{Versions: true})
+	s.Nil(err)
+}
+
+func (s *MinioTestSuite) TestVisibility() {
+	bucket := "visibility"
+	s.Nil(s.minio.instance.MakeBucket(context.Background(), bucket, minio.MakeBucketOptions{}))
+	driver := *s.minio
+	driver.bucket = bucket
+	driver.url = strings.Replace(s.minio.url, testBucket, bucket, 1)
+
+	s.Nil(driver.Put("Visibility/1.txt", "Goravel"))
+	visibility, err := driver.Visibility("Visibility/1.txt")
+	s.Nil(err)
+	s.Equal(VisibilityPrivate, visibility)
+	resp, err := http.Get(driver.Url("Visibility/1.txt"))
+	s.Nil(err)
+	s.Nil(resp.Body.Close())
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+
+	// Single files can't be made public without object ACLs, which are not supported by MinIO.
+	s.NotNil(driver.SetVisibility("Visibility/1.txt", VisibilityPublic))
+	s.NotNil(driver.PutStream("Visibility/2.txt", strings.NewReader("Goravel"), 7, WithVisibility(VisibilityPublic)))
+	s.True(driver.Missing("Visibility/2.txt"))
+	s.Nil(driver.SetVisibility("Visibility/1.txt", VisibilityPrivate))
+
+	s.Nil(driver.SetVisibility("Visibility/", VisibilityPublic))
+	visibility, err = driver.Visibility("Visibility/1.txt")
+	s.Nil(err)
+	s.Equal(VisibilityPublic, visibility)
+	resp, err = http.Get(driver.Url("Visibility/1.txt"))
+	s.Nil(err)
+	s.Nil(resp.Body.Close())
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	s.Nil(driver.SetVisibility("Visibility/1.txt", VisibilityPublic))
+	s.Nil(driver.PutStream("Visibility/2.txt", strings.NewReader("Goravel"), 7, WithVisibility(VisibilityPublic)))
+	visibility, err = driver.Visibility("Visibility/2.txt")
+	s.Nil(err)
+	s.Equal(VisibilityPublic, visibility)
+
+	s.ErrorIs(driver.SetVisibility("Visibility/1.txt", VisibilityPrivate), ErrVisibilityShadowed)
+	s.ErrorIs(driver.SetVisibility("Visibility/a/", VisibilityPrivate), ErrVisibilityShadowed)
+	s.ErrorIs(driver.PutStream("Visibility/3.txt", strings.NewReader("Goravel"), 7, WithVisibility(VisibilityPrivate)), ErrVisibilityShadowed)
+	s.True(driver.Missing("Visibility/3.txt"))
+
+	s.NotNil(driver.SetVisibility("Visibility/1.txt", "unknown"))
+	s.NotNil(driver.PutStream("Visibility/4.txt", strings.NewReader("Goravel"), 7, WithVisibility("unknown")))
+
+	s.Nil(driver.SetVisibility("Visibility/", VisibilityPrivate))
+	policy, err := driver.instance.GetBucketPolicy(context.Background(), bucket)
+	s.Nil(err)
+	s.Empty(policy)
+	visibility, err = driver.Visibility("Visibility/1.txt")
+	s.Nil(err)
+	s.Equal(VisibilityPrivate, visibility)
+
+	s.Nil(driver.DeleteDirectory("Visibility"))
+}
+
+func (s *MinioTestSuite) TestWithContext() {
+	type contextKey string
+	ctx := context.WithValue(context.Background(), contextKey("goravel"), "minio")
+
+	driver, ok := s.minio.WithContext(ctx).(*Minio)
+	s.True(ok)
+	s.Equal(ctx, driver.ctx)
+	s.Same(s.minio.instance, driver.instance)
+	s.Equal(context.Background(), s.minio.ctx)
+
+	s.Nil(driver.Put("WithContext/1.txt", "Goravel"))
+	s.True(s.minio.Exists("WithContext/1.txt"))
+	s.Nil(s.minio.DeleteDirectory("WithContext"))
+}
+
 type File struct {
 	path string
 }
@@ -0,0 +1,161 @@
+package minio
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	RetentionGovernance = "GOVERNANCE"
+	RetentionCompliance = "COMPLIANCE"
+)
+
+// DefaultRetention is the retention applied to the new objects of a bucket, either Days or Years is set.
+type DefaultRetention struct {
+	Mode  string
+	Days  uint
+	Years uint
+}
+
+// MakeBucketOptions configures MakeBucket.
+type MakeBucketOptions struct {
+	// ObjectLock enables the object lock of the bucket, it can only be enabled when creating the bucket,
+	// the versioning of the bucket is enabled as well.
+	ObjectLock bool
+	Region     string
+}
+
+// Retention is the retention of an object, it can't be deleted or overwritten until RetainUntil.
+type Retention struct {
+	// Mode is RetentionGovernance, that can be bypassed by users with the s3:BypassGovernanceRetention
+	// permission, or RetentionCompliance, that can't be bypassed by anyone, not even the root user.
+	Mode        string
+	RetainUntil time.Time
+}
+
+// DefaultRetention gets the default retention of the bucket, it's nil if it is not set.
+func (r *Minio) DefaultRetention() (*DefaultRetention, error) {
+	_, mode, validity, unit, err := r.instance.GetObjectLockConfig(r.ctx, r.bucket)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if mode == nil || validity == nil || unit == nil {
+		return nil, nil
+	}
+
+	retention := &DefaultRetention{Mode: mode.String()}
+	if *unit == minio.Years {
+		retention.Years = *validity
+	} else {
+		retention.Days = *validity
+	}
+
+	return retention, nil
+}
+
+// LegalHold determines if the legal hold of the file is enabled.
+func (r *Minio) LegalHold(file string) (bool, error) {
+	status, err := r.instance.GetObjectLegalHold(r.ctx, r.bucket, r.key(file), minio.GetObjectLegalHoldOptions{})
+	if err != nil {
+		return false, convertError(err)
+	}
+
+	return status != nil && *status == minio.LegalHoldEnabled, nil
+}
+
+// MakeBucket creates the bucket of the disk.
+func (r *Minio) MakeBucket(options MakeBucketOptions) error {
+	return convertError(r.instance.MakeBucket(r.ctx, r.bucket, minio.MakeBucketOptions{
+		ObjectLocking: options.ObjectLock,
+		Region:        options.Region,
+	}))
+}
+
+// RemoveDefaultRetention removes the default retention of the bucket, the retention of existing objects is kept.
+func (r *Minio) RemoveDefaultRetention() error {
+	return convertError(r.instance.SetObjectLockConfig(r.ctx, r.bucket, nil, nil, nil))
+}
+
+// Retention gets the retention of the file, it's nil if it is not set.
+func (r *Minio) Retention(file string) (*Retention, error) {
+	mode, retainUntil, err := r.instance.GetObjectRetention(r.ctx, r.bucket, r.key(file), "")
+	if err != nil {
+		var errResponse minio.ErrorResponse
+		if errors.As(err, &errResponse) && errResponse.Code == "NoSuchObjectLockConfiguration" {
+			return nil, nil
+		}
+
+		return nil, convertError(err)
+	}
+	if mode == nil || retainUntil == nil {
+		return nil, nil
+	}
+
+	return &Retention{
+		Mode:        mode.String(),
+		RetainUntil: *retainUntil,
+	}, nil
+}
+
+// SetDefaultRetention sets the default retention of the bucket, the object lock of the bucket should be enabled.
+func (r *Minio) SetDefaultRetention(retention DefaultRetention) error {
+	mode, err := retentionMode(retention.Mode)
+	if err != nil {
+		return err
+	}
+	if (retention.Days == 0) == (retention.Years == 0) {
+		return errors.New("either the days or years of the default retention should be set")
+	}
+
+	validity, unit := retention.Days, minio.Days
+	if retention.Years > 0 {
+		validity, unit = retention.Years, minio.Years
+	}
+
+	return convertError(r.instance.SetObjectLockConfig(r.ctx, r.bucket, &mode, &validity, &unit))
+}
+
+// SetLegalHold enables or disables the legal hold of the file, a file under legal hold can't be deleted
+// until the legal hold is disabled, regardless of its retention.
+func (r *Minio) SetLegalHold(file string, enabled bool) error {
+	status := minio.LegalHoldDisabled
+	if enabled {
+		status = minio.LegalHoldEnabled
+	}
+
+	return convertError(r.instance.PutObjectLegalHold(r.ctx, r.bucket, r.key(file), minio.PutObjectLegalHoldOptions{
+		Status: &status,
+	}))
+}
+
+// SetRetention sets the retention of the file. The retention can only be extended, unless the mode is
+// RetentionGovernance and bypassGovernance is true.
+func (r *Minio) SetRetention(file string, retention Retention, bypassGovernance bool) error {
+	mode, err := retentionMode(retention.Mode)
+	if err != nil {
+		return err
+	}
+	if retention.RetainUntil.IsZero() {
+		return errors.New("the retain until date of the retention is required")
+	}
+
+	retainUntil := retention.RetainUntil.UTC()
+
+	return convertError(r.instance.PutObjectRetention(r.ctx, r.bucket, r.key(file), minio.PutObjectRetentionOptions{
+		GovernanceBypass: bypassGovernance,
+		Mode:             &mode,
+		RetainUntilDate:  &retainUntil,
+	}))
+}
+
+func retentionMode(mode string) (minio.RetentionMode, error) {
+	retentionMode := minio.RetentionMode(mode)
+	if !retentionMode.IsValid() {
+		return "", fmt.Errorf("invalid retention mode %s, it should be %s or %s", mode, RetentionGovernance, RetentionCompliance)
+	}
+
+	return retentionMode, nil
+}
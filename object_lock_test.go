@@ -0,0 +1,21 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionMode(t *testing.T) {
+	mode, err := retentionMode(RetentionGovernance)
+	assert.Nil(t, err)
+	assert.Equal(t, minio.Governance, mode)
+
+	mode, err = retentionMode(RetentionCompliance)
+	assert.Nil(t, err)
+	assert.Equal(t, minio.Compliance, mode)
+
+	_, err = retentionMode("governance")
+	assert.NotNil(t, err)
+}
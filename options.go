@@ -0,0 +1,120 @@
+package minio
+
+// PutOption configures the object written by PutWithOptions, PutStream, PutFileWithOptions and PutFileAsWithOptions.
+type PutOption func(*putOptions)
+
+type putOptions struct {
+	// acl is the canned ACL of the written object, it's only set by the driver if the acl of the disk is enabled.
+	acl                string
+	cacheControl       string
+	contentDisposition string
+	contentEncoding    string
+	contentType        string
+	metadata           map[string]string
+	partSize           uint64
+	tags               map[string]string
+	visibility         string
+}
+
+// WithCacheControl sets the Cache-Control header of the written object.
+func WithCacheControl(cacheControl string) PutOption {
+	return func(options *putOptions) {
+		options.cacheControl = cacheControl
+	}
+}
+
+// WithContentDisposition sets the Content-Disposition header of the written object.
+func WithContentDisposition(contentDisposition string) PutOption {
+	return func(options *putOptions) {
+		options.contentDisposition = contentDisposition
+	}
+}
+
+// WithContentEncoding sets the Content-Encoding header of the written object.
+func WithContentEncoding(contentEncoding string) PutOption {
+	return func(options *putOptions) {
+		options.contentEncoding = contentEncoding
+	}
+}
+
+// WithContentType sets the Content-Type of the written object, it's detected from the content by default.
+func WithContentType(contentType string) PutOption {
+	return func(options *putOptions) {
+		options.contentType = contentType
+	}
+}
+
+// WithMetadata adds user metadata to the written object, they are sent as x-amz-meta-* headers.
+func WithMetadata(metadata map[string]string) PutOption {
+	return func(options *putOptions) {
+		if options.metadata == nil {
+			options.metadata = make(map[string]string)
+		}
+		for key, value := range metadata {
+			options.metadata[key] = value
+		}
+	}
+}
+
+// WithPartSize sets the size of the parts when the content is uploaded via multipart, a buffer of the size
+// is allocated for every upload of unknown size, which can be up to 10000 parts.
+func WithPartSize(partSize uint64) PutOption {
+	return func(options *putOptions) {
+		options.partSize = partSize
+	}
+}
+
+// WithTags adds tags to the written object.
+func WithTags(tags map[string]string) PutOption {
+	return func(options *putOptions) {
+		if options.tags == nil {
+			options.tags = make(map[string]string)
+		}
+		for key, value := range tags {
+			options.tags[key] = value
+		}
+	}
+}
+
+// WithVisibility sets the visibility of the written object, VisibilityPublic or VisibilityPrivate.
+func WithVisibility(visibility string) PutOption {
+	return func(options *putOptions) {
+		options.visibility = visibility
+	}
+}
+
+func newPutOptions(options []PutOption) *putOptions {
+	opts := &putOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// headers converts the options to the user metadata of minio, the standard headers are sent as they are.
+func (r *putOptions) headers() map[string]string {
+	headers := make(map[string]string, len(r.metadata)+5)
+	for key, value := range r.metadata {
+		headers[key] = value
+	}
+	for key, value := range map[string]string{
+		"Cache-Control":       r.cacheControl,
+		"Content-Disposition": r.contentDisposition,
+		"Content-Encoding":    r.contentEncoding,
+		"Content-Type":        r.contentType,
+		"X-Amz-Acl":           r.acl,
+	} {
+		if value != "" {
+			headers[key] = value
+		}
+	}
+
+	return headers
+}
+
+// replaces determines if any header, metadata, tag or ACL is set by the options.
+func (r *putOptions) replaces() bool {
+	return r.acl != "" || r.cacheControl != "" || r.contentDisposition != "" || r.contentEncoding != "" || r.contentType != "" ||
+		r.metadata != nil || r.tags != nil
+}
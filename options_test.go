@@ -0,0 +1,63 @@
+package minio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPutOptions(t *testing.T) {
+	opts := newPutOptions(nil)
+	assert.Equal(t, &putOptions{}, opts)
+
+	opts = newPutOptions([]PutOption{
+		WithCacheControl("max-age=3600"),
+		WithContentDisposition("attachment; filename=goravel.txt"),
+		WithContentEncoding("gzip"),
+		WithContentType("text/plain"),
+		WithMetadata(map[string]string{"a": "1"}),
+		WithMetadata(map[string]string{"b": "2"}),
+		WithPartSize(32 << 20),
+		WithTags(map[string]string{"c": "3"}),
+		WithVisibility(VisibilityPublic),
+	})
+	assert.Equal(t, &putOptions{
+		cacheControl:       "max-age=3600",
+		contentDisposition: "attachment; filename=goravel.txt",
+		contentEncoding:    "gzip",
+		contentType:        "text/plain",
+		metadata:           map[string]string{"a": "1", "b": "2"},
+		partSize:           32 << 20,
+		tags:               map[string]string{"c": "3"},
+		visibility:         VisibilityPublic,
+	}, opts)
+}
+
+func TestPutOptionsHeaders(t *testing.T) {
+	assert.Empty(t, (&putOptions{}).headers())
+
+	opts := newPutOptions([]PutOption{
+		WithCacheControl("no-cache"),
+		WithContentType("text/plain"),
+		WithMetadata(map[string]string{"Author": "goravel"}),
+		WithTags(map[string]string{"env": "test"}),
+	})
+	assert.Equal(t, map[string]string{
+		"Author":        "goravel",
+		"Cache-Control": "no-cache",
+		"Content-Type":  "text/plain",
+	}, opts.headers())
+}
+
+func TestPutOptionsHeaders_ACL(t *testing.T) {
+	assert.Equal(t, map[string]string{"X-Amz-Acl": "public-read"}, (&putOptions{acl: "public-read"}).headers())
+}
+
+func TestPutOptionsReplaces(t *testing.T) {
+	assert.False(t, newPutOptions(nil).replaces())
+	assert.False(t, newPutOptions([]PutOption{WithVisibility(VisibilityPublic)}).replaces())
+	assert.True(t, newPutOptions([]PutOption{WithContentType("text/plain")}).replaces())
+	assert.True(t, newPutOptions([]PutOption{WithMetadata(nil)}).replaces())
+	assert.True(t, newPutOptions([]PutOption{WithTags(map[string]string{"env": "test"})}).replaces())
+	assert.True(t, (&putOptions{acl: "public-read"}).replaces())
+}
@@ -0,0 +1,244 @@
+package minio
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/support/carbon"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// PresignedPost is the url an HTML form should be posted to and the fields it must contain, the file
+// field must be the last one of the form.
+type PresignedPost struct {
+	Fields map[string]string
+	Url    string
+}
+
+// PostPolicy builds a presigned POST policy to upload a file via an HTML form, all constraints are
+// validated when calling Presign.
+type PostPolicy struct {
+	minio                 *Minio
+	contentType           string
+	contentTypeStartsWith string
+	expires               time.Time
+	key                   string
+	keyStartsWith         string
+	maxLength             int64
+	metadata              map[string]string
+	minLength             int64
+	successActionRedirect string
+	successActionStatus   int
+}
+
+// PresignedPostPolicy creates a POST policy builder for the bucket of the disk.
+func (r *Minio) PresignedPostPolicy() *PostPolicy {
+	return &PostPolicy{
+		minio:    r,
+		metadata: make(map[string]string),
+	}
+}
+
+// ContentLengthRange limits the size of the uploaded file in bytes.
+func (r *PostPolicy) ContentLengthRange(minLength, maxLength int64) *PostPolicy {
+	r.minLength = minLength
+	r.maxLength = maxLength
+
+	return r
+}
+
+// ContentType requires the exact content type of the uploaded file.
+func (r *PostPolicy) ContentType(contentType string) *PostPolicy {
+	r.contentType = contentType
+
+	return r
+}
+
+// ContentTypeStartsWith requires the content type of the uploaded file to start with the prefix, for example, image/.
+// The Content-Type field of the form should be replaced by the real content type.
+func (r *PostPolicy) ContentTypeStartsWith(prefix string) *PostPolicy {
+	r.contentTypeStartsWith = prefix
+
+	return r
+}
+
+// Expires sets when the policy expires.
+func (r *PostPolicy) Expires(time time.Time) *PostPolicy {
+	r.expires = time
+
+	return r
+}
+
+// Key requires the exact key of the uploaded file.
+func (r *PostPolicy) Key(key string) *PostPolicy {
+	r.key = strings.TrimPrefix(key, "/")
+
+	return r
+}
+
+// KeyStartsWith requires the key of the uploaded file to start with the prefix, the key field of the form
+// should be replaced by the real key, or end with ${filename} to use the name of the uploaded file.
+// The key field already contains the root of the disk.
+func (r *PostPolicy) KeyStartsWith(prefix string) *PostPolicy {
+	r.keyStartsWith = strings.TrimPrefix(prefix, "/")
+
+	return r
+}
+
+// Metadata requires the user metadata to be set to the uploaded file.
+func (r *PostPolicy) Metadata(key, value string) *PostPolicy {
+	r.metadata[key] = value
+
+	return r
+}
+
+// SuccessActionRedirect sets the url the client is redirected to after the upload succeeds.
+func (r *PostPolicy) SuccessActionRedirect(url string) *PostPolicy {
+	r.successActionRedirect = url
+
+	return r
+}
+
+// SuccessActionStatus sets the status code returned after the upload succeeds, 200, 201 or 204.
+func (r *PostPolicy) SuccessActionStatus(status int) *PostPolicy {
+	r.successActionStatus = status
+
+	return r
+}
+
+// Presign validates the constraints and signs the policy.
+func (r *PostPolicy) Presign() (*PresignedPost, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	encryption, err := r.minio.presignEncryption()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := minio.NewPostPolicy()
+	if err := setPostPolicyEncryption(policy, encryption); err != nil {
+		return nil, err
+	}
+	if err := policy.SetBucket(r.minio.bucket); err != nil {
+		return nil, err
+	}
+	if err := policy.SetExpires(r.expires.UTC()); err != nil {
+		return nil, err
+	}
+	if r.key != "" {
+		if err := policy.SetKey(r.minio.key(r.key)); err != nil {
+			return nil, err
+		}
+	} else if err := policy.SetKeyStartsWith(r.minio.root + r.keyStartsWith); err != nil {
+		return nil, err
+	}
+	if r.contentType != "" {
+		if err := policy.SetContentType(r.contentType); err != nil {
+			return nil, err
+		}
+	} else if r.contentTypeStartsWith != "" {
+		if err := policy.SetContentTypeStartsWith(r.contentTypeStartsWith); err != nil {
+			return nil, err
+		}
+	}
+	if r.maxLength > 0 {
+		if err := policy.SetContentLengthRange(r.minLength, r.maxLength); err != nil {
+			return nil, err
+		}
+	}
+	if r.successActionRedirect != "" {
+		if err := policy.SetSuccessActionRedirect(r.successActionRedirect); err != nil {
+			return nil, err
+		}
+	}
+	if r.successActionStatus != 0 {
+		if err := policy.SetSuccessStatusAction(strconv.Itoa(r.successActionStatus)); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range r.metadata {
+		if err := policy.SetUserMetadata(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	presignedURL, fields, err := r.minio.instance.PresignedPostPolicy(r.minio.ctx, policy)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return &PresignedPost{
+		Fields: fields,
+		Url:    presignedURL.String(),
+	}, nil
+}
+
+func (r *PostPolicy) validate() error {
+	if r.expires.IsZero() {
+		return errors.New("the expiration of the post policy is required")
+	}
+	if !r.expires.After(carbon.Now().StdTime()) {
+		return errors.New("the expiration of the post policy should be in the future")
+	}
+
+	if r.key == "" && r.keyStartsWith == "" {
+		return errors.New("the key or key prefix of the post policy is required")
+	}
+	if r.key != "" && r.keyStartsWith != "" && !strings.HasPrefix(r.key, r.keyStartsWith) {
+		return fmt.Errorf("the key %s doesn't start with the key prefix %s", r.key, r.keyStartsWith)
+	}
+
+	if r.contentType != "" && r.contentTypeStartsWith != "" && !strings.HasPrefix(r.contentType, r.contentTypeStartsWith) {
+		return fmt.Errorf("the content type %s doesn't start with the content type prefix %s", r.contentType, r.contentTypeStartsWith)
+	}
+
+	if r.minLength != 0 || r.maxLength != 0 {
+		if r.minLength < 0 {
+			return fmt.Errorf("the minimum content length %d should be greater than or equal to 0", r.minLength)
+		}
+		if r.maxLength <= 0 {
+			return fmt.Errorf("the maximum content length %d should be greater than 0", r.maxLength)
+		}
+		if r.minLength > r.maxLength {
+			return fmt.Errorf("the minimum content length %d is greater than the maximum content length %d", r.minLength, r.maxLength)
+		}
+	}
+
+	if r.successActionStatus != 0 && r.successActionStatus != 200 && r.successActionStatus != 201 && r.successActionStatus != 204 {
+		return fmt.Errorf("invalid success action status %d, it should be 200, 201 or 204", r.successActionStatus)
+	}
+
+	for key, value := range r.metadata {
+		if strings.TrimSpace(key) == "" || strings.TrimSpace(value) == "" {
+			return fmt.Errorf("the key and value of the metadata can't be empty, key: %s, value: %s", key, value)
+		}
+	}
+
+	return nil
+}
+
+// setPostPolicyEncryption adds the headers of the encryption as both the fields of the form and the conditions
+// of the policy, minio.PostPolicy.SetEncryption only adds the fields, so the client could remove or change them.
+func setPostPolicyEncryption(policy *minio.PostPolicy, encryption encrypt.ServerSide) error {
+	if encryption == nil {
+		return nil
+	}
+
+	header := make(http.Header)
+	encryption.Marshal(header)
+	for key := range header {
+		// SetUserData prefixes the field with x-amz-.
+		if err := policy.SetUserData(strings.TrimPrefix(strings.ToLower(key), "x-amz-"), header.Get(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package minio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/support/carbon"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostPolicyValidate(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		policy    func(policy *PostPolicy) *PostPolicy
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).
+					KeyStartsWith("uploads/").
+					ContentTypeStartsWith("image/").
+					ContentLengthRange(1, 1024).
+					SuccessActionStatus(201).
+					Metadata("author", "goravel")
+			},
+		},
+		{
+			name: "without expiration",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Key("uploads/1.png")
+			},
+			expectErr: true,
+		},
+		{
+			name: "expired",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(time.Now().Add(-time.Second)).Key("uploads/1.png")
+			},
+			expectErr: true,
+		},
+		{
+			name: "without key",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires)
+			},
+			expectErr: true,
+		},
+		{
+			name: "key doesn't match the key prefix",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("avatars/1.png").KeyStartsWith("uploads/")
+			},
+			expectErr: true,
+		},
+		{
+			name: "content type doesn't match the content type prefix",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("uploads/1.png").ContentType("text/plain").ContentTypeStartsWith("image/")
+			},
+			expectErr: true,
+		},
+		{
+			name: "minimum length is greater than maximum length",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("uploads/1.png").ContentLengthRange(1024, 1)
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative minimum length",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("uploads/1.png").ContentLengthRange(-1, 1)
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid success status",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("uploads/1.png").SuccessActionStatus(302)
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty metadata",
+			policy: func(policy *PostPolicy) *PostPolicy {
+				return policy.Expires(expires).Key("uploads/1.png").Metadata("author", "")
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy((&Minio{}).PresignedPostPolicy()).validate()
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPostPolicyValidate_TestNow(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	carbon.SetTestNow(carbon.FromStdTime(expires.Add(time.Minute)))
+	defer carbon.ClearTestNow()
+
+	assert.NotNil(t, (&PostPolicy{}).Expires(expires).Key("uploads/1.png").validate())
+}
+
+func TestSetPostPolicyEncryption(t *testing.T) {
+	policy := minio.NewPostPolicy()
+	assert.Nil(t, setPostPolicyEncryption(policy, nil))
+	assert.NotContains(t, policy.String(), "server-side-encryption")
+
+	policy = minio.NewPostPolicy()
+	assert.Nil(t, setPostPolicyEncryption(policy, encrypt.NewSSE()))
+	assert.Contains(t, policy.String(), `["eq","$x-amz-server-side-encryption","AES256"]`)
+
+	kms, err := encrypt.NewSSEKMS("goravel", nil)
+	assert.Nil(t, err)
+	policy = minio.NewPostPolicy()
+	assert.Nil(t, setPostPolicyEncryption(policy, kms))
+	assert.Contains(t, policy.String(), `["eq","$x-amz-server-side-encryption","aws:kms"]`)
+	assert.Contains(t, policy.String(), `["eq","$x-amz-server-side-encryption-aws-kms-key-id","goravel"]`)
+}
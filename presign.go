@@ -0,0 +1,122 @@
+package minio
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/support/carbon"
+)
+
+// PresignedRequest is a presigned url and the headers the client must send with it, since they are signed.
+type PresignedRequest struct {
+	Headers map[string]string
+	Method  string
+	Url     string
+}
+
+// TemporaryUrlOptions overrides the response headers of a temporary url.
+type TemporaryUrlOptions struct {
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+	// DownloadName forces the file to be downloaded with the name, it's ignored if ContentDisposition is set.
+	DownloadName string
+	Expires      string
+}
+
+// TemporaryUploadOptions constrains the upload via a temporary upload url.
+type TemporaryUploadOptions struct {
+	// ContentLength is the exact size of the uploaded content, it's not constrained if it is 0.
+	ContentLength int64
+	// ContentType is the exact content type of the uploaded content, it's not constrained if it is empty.
+	ContentType string
+	// Metadata is the user metadata that will be set to the uploaded file.
+	Metadata map[string]string
+}
+
+// TemporaryUploadUrl gets a temporary url to upload the file via PUT directly, for example, from a browser.
+func (r *Minio) TemporaryUploadUrl(file string, time time.Time, options TemporaryUploadOptions) (*PresignedRequest, error) {
+	if options.ContentLength < 0 {
+		return nil, fmt.Errorf("invalid content length %d, it should be greater than or equal to 0", options.ContentLength)
+	}
+
+	encryption, err := r.presignEncryption()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(http.Header)
+	if encryption != nil {
+		encryption.Marshal(headers)
+	}
+	if options.ContentType != "" {
+		headers.Set("Content-Type", options.ContentType)
+	}
+	if options.ContentLength > 0 {
+		headers.Set("Content-Length", fmt.Sprintf("%d", options.ContentLength))
+	}
+	for key, value := range options.Metadata {
+		headers.Set("X-Amz-Meta-"+key, value)
+	}
+
+	file = strings.TrimPrefix(r.key(file), "/")
+	presignedURL, err := r.instance.PresignHeader(r.ctx, http.MethodPut, r.bucket, file, time.Sub(carbon.Now().StdTime()), nil, headers)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	signedHeaders := make(map[string]string, len(headers))
+	for key := range headers {
+		signedHeaders[key] = headers.Get(key)
+	}
+
+	return &PresignedRequest{
+		Headers: signedHeaders,
+		Method:  http.MethodPut,
+		Url:     presignedURL.String(),
+	}, nil
+}
+
+// TemporaryUrlFor gets a temporary url of the file that expires after the duration.
+func (r *Minio) TemporaryUrlFor(file string, expiry time.Duration, options TemporaryUrlOptions) (string, error) {
+	if _, err := r.presignEncryption(); err != nil {
+		return "", err
+	}
+
+	reqParams := make(url.Values)
+	contentDisposition := options.ContentDisposition
+	if contentDisposition == "" && options.DownloadName != "" {
+		contentDisposition = mime.FormatMediaType("attachment", map[string]string{"filename": options.DownloadName})
+	}
+	for key, value := range map[string]string{
+		"response-cache-control":       options.CacheControl,
+		"response-content-disposition": contentDisposition,
+		"response-content-encoding":    options.ContentEncoding,
+		"response-content-language":    options.ContentLanguage,
+		"response-content-type":        options.ContentType,
+		"response-expires":             options.Expires,
+	} {
+		if value != "" {
+			reqParams.Set(key, value)
+		}
+	}
+
+	file = strings.TrimPrefix(r.key(file), "/")
+	presignedURL, err := r.instance.PresignedGetObject(r.ctx, r.bucket, file, expiry, reqParams)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return presignedURL.String(), nil
+}
+
+// TemporaryUrlWithOptions gets a temporary url of the file with the response headers overridden.
+func (r *Minio) TemporaryUrlWithOptions(file string, time time.Time, options TemporaryUrlOptions) (string, error) {
+	return r.TemporaryUrlFor(file, time.Sub(carbon.Now().StdTime()), options)
+}
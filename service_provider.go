@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/goravel/framework/contracts/binding"
+	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/foundation"
 )
 
@@ -40,4 +41,17 @@ func (r *ServiceProvider) Boot(app foundation.Application) {
 	app.Publishes("github.com/goravel/minio", map[string]string{
 		"config/minio.go": app.ConfigPath(""),
 	})
+
+	config := app.MakeConfig()
+	app.Commands([]console.Command{
+		NewLifecycleCommand(config),
+	})
+
+	if errs := reconcileLifecycleOnBoot(config); len(errs) > 0 {
+		if log := app.MakeLog(); log != nil {
+			for _, err := range errs {
+				log.Error(err)
+			}
+		}
+	}
 }
@@ -0,0 +1,107 @@
+package minio
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/minio/minio-go/v7"
+)
+
+// newTransport builds the HTTP transport of the disk from the `http` block of the disk configuration.
+// A custom http.RoundTripper can be set via `http.transport`, then the other options are ignored.
+func newTransport(config config.Config, disk string, secure bool) (http.RoundTripper, error) {
+	prefix := fmt.Sprintf("filesystems.disks.%s.http", disk)
+	if roundTripper, ok := config.Get(prefix + ".transport").(http.RoundTripper); ok && roundTripper != nil {
+		return roundTripper, nil
+	}
+
+	transport, err := minio.DefaultTransport(secure)
+	if err != nil {
+		return nil, err
+	}
+
+	if dialTimeout := config.GetDuration(prefix + ".dial_timeout"); dialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if responseHeaderTimeout := config.GetDuration(prefix + ".response_header_timeout"); responseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = responseHeaderTimeout
+	}
+	if idleConnTimeout := config.GetDuration(prefix + ".idle_conn_timeout"); idleConnTimeout > 0 {
+		transport.IdleConnTimeout = idleConnTimeout
+	}
+	if maxIdleConns := config.GetInt(prefix + ".max_idle_conns"); maxIdleConns > 0 {
+		transport.MaxIdleConns = maxIdleConns
+		transport.MaxIdleConnsPerHost = maxIdleConns
+	}
+
+	if proxy := config.GetString(prefix + ".proxy"); proxy != "" {
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http.proxy of %s disk: %w", disk, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	tlsConfig, err := newTLSConfig(config, disk, transport.TLSClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func newTLSConfig(config config.Config, disk string, tlsConfig *tls.Config) (*tls.Config, error) {
+	prefix := fmt.Sprintf("filesystems.disks.%s.http", disk)
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	caPem := []byte(config.GetString(prefix + ".ca_pem"))
+	if caFile := config.GetString(prefix + ".ca_file"); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read http.ca_file of %s disk error: %w", disk, err)
+		}
+		caPem = append(caPem, data...)
+	}
+	if len(caPem) > 0 {
+		rootCAs := tlsConfig.RootCAs
+		if rootCAs == nil {
+			var err error
+			if rootCAs, err = x509.SystemCertPool(); err != nil {
+				rootCAs = x509.NewCertPool()
+			}
+		}
+		if !rootCAs.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no valid certificate is found in http.ca_file or http.ca_pem of %s disk", disk)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	certFile := config.GetString(prefix + ".cert_file")
+	keyFile := config.GetString(prefix + ".key_file")
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load http.cert_file and http.key_file of %s disk error: %w", disk, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	tlsConfig.InsecureSkipVerify = config.GetBool(prefix+".insecure_skip_verify", false)
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,96 @@
+package minio
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	configmock "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Run("custom transport", func(t *testing.T) {
+		roundTripper := &http.Transport{}
+		mockConfig := configmock.NewConfig(t)
+		mockConfig.EXPECT().Get("filesystems.disks.minio.http.transport").Return(roundTripper).Once()
+
+		transport, err := newTransport(mockConfig, "minio", true)
+		assert.Nil(t, err)
+		assert.Same(t, roundTripper, transport)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		mockConfig := configmock.NewConfig(t)
+		mockConfig.EXPECT().Get("filesystems.disks.minio.http.transport").Return(nil).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.dial_timeout").Return(5 * time.Second).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.response_header_timeout").Return(10 * time.Second).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.idle_conn_timeout").Return(time.Duration(0)).Once()
+		mockConfig.EXPECT().GetInt("filesystems.disks.minio.http.max_idle_conns").Return(32).Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.proxy").Return("http://127.0.0.1:8080").Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.ca_pem").Return(testCertificate(t)).Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.ca_file").Return("").Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.cert_file").Return("").Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.key_file").Return("").Once()
+		mockConfig.EXPECT().GetBool("filesystems.disks.minio.http.insecure_skip_verify", false).Return(true).Once()
+
+		roundTripper, err := newTransport(mockConfig, "minio", true)
+		assert.Nil(t, err)
+
+		transport, ok := roundTripper.(*http.Transport)
+		assert.True(t, ok)
+		assert.Equal(t, 10*time.Second, transport.ResponseHeaderTimeout)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+		assert.Equal(t, 32, transport.MaxIdleConns)
+		assert.Equal(t, 32, transport.MaxIdleConnsPerHost)
+
+		proxyUrl, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "minio.goravel.dev"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "http://127.0.0.1:8080", proxyUrl.String())
+
+		assert.NotNil(t, transport.TLSClientConfig.RootCAs)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	})
+
+	t.Run("invalid ca", func(t *testing.T) {
+		mockConfig := configmock.NewConfig(t)
+		mockConfig.EXPECT().Get("filesystems.disks.minio.http.transport").Return(nil).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.dial_timeout").Return(time.Duration(0)).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.response_header_timeout").Return(time.Duration(0)).Once()
+		mockConfig.EXPECT().GetDuration("filesystems.disks.minio.http.idle_conn_timeout").Return(time.Duration(0)).Once()
+		mockConfig.EXPECT().GetInt("filesystems.disks.minio.http.max_idle_conns").Return(0).Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.proxy").Return("").Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.ca_pem").Return("invalid").Once()
+		mockConfig.EXPECT().GetString("filesystems.disks.minio.http.ca_file").Return("").Once()
+
+		transport, err := newTransport(mockConfig, "minio", false)
+		assert.NotNil(t, err)
+		assert.Nil(t, transport)
+	})
+}
+
+func testCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "goravel"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -1,14 +1,50 @@
 package minio
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"path"
 	"strings"
 
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/goravel/framework/contracts/filesystem"
 	"github.com/goravel/framework/support/file"
 )
 
+// detectContentType detects the content type from the head of the reader and returns a reader of the whole
+// content. A seekable reader is rewound instead of being wrapped, so the io.ReaderAt of an *os.File is kept
+// and minio can upload its parts in parallel.
+func detectContentType(reader io.Reader) (string, io.Reader, error) {
+	seeker, seekable := reader.(io.Seeker)
+	var offset int64
+	if seekable {
+		var err error
+		if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
+	}
+
+	head := make([]byte, mimetypeReadLimit)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	contentType := mimetype.Detect(head).String()
+
+	if seekable {
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return "", nil, err
+		}
+
+		return contentType, reader, nil
+	}
+
+	return contentType, io.MultiReader(bytes.NewReader(head), reader), nil
+}
+
 func fullPathOfFile(filePath string, source filesystem.File, name string) (string, error) {
 	extension := path.Ext(name)
 	if extension == "" {
@@ -1,11 +1,44 @@
 package minio
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestDetectContentType(t *testing.T) {
+	content := strings.Repeat("Goravel", 1000)
+	contentType, reader, err := detectContentType(io.MultiReader(strings.NewReader(content)))
+	assert.Nil(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", contentType)
+	data, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, content, string(data))
+
+	file, err := os.Open("logo.png")
+	assert.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+	_, err = file.Seek(1, io.SeekStart)
+	assert.Nil(t, err)
+	_, reader, err = detectContentType(file)
+	assert.Nil(t, err)
+	assert.Same(t, file, reader)
+	offset, err := file.Seek(0, io.SeekCurrent)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), offset)
+
+	_, err = file.Seek(0, io.SeekStart)
+	assert.Nil(t, err)
+	contentType, _, err = detectContentType(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", contentType)
+}
+
 func TestFullPathOfFile(t *testing.T) {
 	path, err := fullPathOfFile("a/b", &File{path: "logo.png"}, "c")
 	assert.Equal(t, "a/b/c.png", path)
@@ -0,0 +1,133 @@
+package minio
+
+import (
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	VersioningEnabled   = "Enabled"
+	VersioningSuspended = "Suspended"
+)
+
+// ObjectVersion is a version of a file returned by Versions.
+type ObjectVersion struct {
+	ETag string
+	// IsDeleteMarker is true if the version is the marker added when the file is deleted, it has no content.
+	IsDeleteMarker bool
+	IsLatest       bool
+	// LastModified is in the timezone of the application.
+	LastModified time.Time
+	// Size is the stored size, for versions encrypted on the client side it's the size of the encrypted content.
+	Size      int64
+	VersionID string
+}
+
+// DeleteVersion deletes the version of the file permanently, deleting the delete marker restores the
+// previous version of a deleted file.
+func (r *Minio) DeleteVersion(file, versionID string) error {
+	return convertError(r.instance.RemoveObject(r.ctx, r.bucket, r.key(file), minio.RemoveObjectOptions{
+		VersionID: versionID,
+	}))
+}
+
+// EnableVersioning enables the versioning of the bucket, it can be suspended but not disabled afterwards.
+func (r *Minio) EnableVersioning() error {
+	return convertError(r.instance.EnableVersioning(r.ctx, r.bucket))
+}
+
+// GetVersion gets the content of the version of the file.
+func (r *Minio) GetVersion(file, versionID string) (string, error) {
+	object, err := r.getObject(file, minio.GetObjectOptions{VersionID: versionID})
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = object.Close()
+	}()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// RestoreVersion makes the version of the file the latest one by copying it, the newer versions are kept.
+// The headers, metadata and tags of the version are restored as well.
+func (r *Minio) RestoreVersion(file, versionID string) error {
+	info, err := r.instance.StatObject(r.ctx, r.bucket, r.key(file), minio.StatObjectOptions{
+		VersionID:            versionID,
+		ServerSideEncryption: r.readEncryption(),
+	})
+	if err != nil {
+		return convertError(err)
+	}
+
+	srcOpts := minio.CopySrcOptions{
+		Bucket:     r.bucket,
+		Object:     r.key(file),
+		VersionID:  versionID,
+		Encryption: r.readEncryption(),
+	}
+	dstOpts := minio.CopyDestOptions{
+		Bucket:     r.bucket,
+		Object:     r.key(file),
+		Encryption: r.encryption,
+	}
+
+	return r.composeObject(file, info, srcOpts, dstOpts, &putOptions{})
+}
+
+// SuspendVersioning suspends the versioning of the bucket, the existing versions are kept.
+func (r *Minio) SuspendVersioning() error {
+	return convertError(r.instance.SuspendVersioning(r.ctx, r.bucket))
+}
+
+// Versions gets the versions of the file, including the delete markers, from the newest to the oldest.
+func (r *Minio) Versions(file string) ([]ObjectVersion, error) {
+	l, err := time.LoadLocation(r.timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	key := r.key(file)
+	var versions []ObjectVersion
+	for object := range r.instance.ListObjects(r.ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:       key,
+		WithVersions: true,
+	}) {
+		if object.Err != nil {
+			return nil, convertError(object.Err)
+		}
+		// The prefix matches the files starting with the key as well.
+		if object.Key != key {
+			continue
+		}
+
+		versions = append(versions, ObjectVersion{
+			ETag:           object.ETag,
+			IsDeleteMarker: object.IsDeleteMarker,
+			IsLatest:       object.IsLatest,
+			LastModified:   object.LastModified.In(l),
+			Size:           object.Size,
+			VersionID:      object.VersionID,
+		})
+	}
+
+	return versions, nil
+}
+
+// VersioningStatus gets the versioning status of the bucket, VersioningEnabled, VersioningSuspended or
+// an empty string if it has never been enabled.
+func (r *Minio) VersioningStatus() (string, error) {
+	configuration, err := r.instance.GetBucketVersioning(r.ctx, r.bucket)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return configuration.Status, nil
+}
@@ -0,0 +1,334 @@
+package minio
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+const (
+	// allUsersURI is the grantee of the object ACLs that allow anonymous access.
+	allUsersURI = "http://acs.amazonaws.com/groups/global/AllUsers"
+	// visibilitySid identifies the bucket policy statement managed by the driver.
+	visibilitySid = "GoravelPublicVisibility"
+)
+
+// SetVisibility sets the visibility of the file, or of every file under it if the path ends with "/".
+// Object ACLs are not supported by MinIO and are disabled by default on AWS S3, so directories are made public
+// via a statement of the bucket policy, and single files only if the acl of the disk is enabled, since a bucket
+// policy is limited to 20 KB. The object ACL is set by copying the file onto itself, so its last modified time
+// is updated. The bucket policy is read, modified and written back, concurrent calls may override each other.
+func (r *Minio) SetVisibility(path, visibility string) error {
+	if err := validVisibility(visibility); err != nil {
+		return err
+	}
+
+	resource := visibilityResource(r.bucket, r.key(path))
+	directory := strings.HasSuffix(resource, "*")
+	if !directory && (r.acl || visibility == VisibilityPublic) {
+		if err := r.checkVisibility(path, visibility); err != nil {
+			return err
+		}
+		if r.acl {
+			return r.copyTo(path, r, path, &putOptions{acl: visibilityACL(visibility)})
+		}
+
+		return nil
+	}
+
+	policy, err := r.bucketPolicy()
+	if err != nil {
+		return err
+	}
+
+	var statements []any
+	var resources []string
+	for _, item := range policyStatements(policy) {
+		statement, ok := item.(map[string]any)
+		if ok && statement["Sid"] == visibilitySid {
+			resources = policyStrings(statement["Resource"])
+			continue
+		}
+		statements = append(statements, item)
+	}
+
+	// Single files may be granted by the previous versions, they can be made private as well.
+	var newResources []string
+	for _, item := range resources {
+		if item != resource {
+			newResources = append(newResources, item)
+		}
+	}
+	if visibility == VisibilityPublic {
+		newResources = append(newResources, resource)
+	}
+	if len(newResources) > 0 {
+		statements = append(statements, map[string]any{
+			"Sid":       visibilitySid,
+			"Effect":    "Allow",
+			"Principal": map[string]any{"AWS": []string{"*"}},
+			"Action":    []string{"s3:GetObject"},
+			"Resource":  newResources,
+		})
+	}
+
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = "2012-10-17"
+	}
+	policy["Statement"] = statements
+	if visibility == VisibilityPrivate && isPublicResource(policy, resource) {
+		return fmt.Errorf("%w: %s", ErrVisibilityShadowed, path)
+	}
+	if slices.Equal(resources, newResources) {
+		return nil
+	}
+	if len(statements) == 0 {
+		return convertError(r.instance.SetBucketPolicy(r.ctx, r.bucket, ""))
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	return convertError(r.instance.SetBucketPolicy(r.ctx, r.bucket, string(data)))
+}
+
+// Visibility gets the visibility of the file, it's public if the bucket policy grants anonymous read access to it,
+// or, if the acl of the disk is enabled, its object ACL does.
+func (r *Minio) Visibility(file string) (string, error) {
+	policy, err := r.bucketPolicy()
+	if err != nil {
+		return "", err
+	}
+
+	resource := visibilityResource(r.bucket, r.key(file))
+	if isPublicResource(policy, resource) {
+		return VisibilityPublic, nil
+	}
+
+	if r.acl && !strings.HasSuffix(resource, "*") {
+		info, err := r.instance.GetObjectACL(r.ctx, r.bucket, r.key(file))
+		if err != nil {
+			return "", convertError(err)
+		}
+		if isPublicGrant(info.Grant) {
+			return VisibilityPublic, nil
+		}
+	}
+
+	return VisibilityPrivate, nil
+}
+
+func (r *Minio) bucketPolicy() (map[string]any, error) {
+	data, err := r.instance.GetBucketPolicy(r.ctx, r.bucket)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	policy := make(map[string]any)
+	if data == "" {
+		return policy, nil
+	}
+	if err := json.Unmarshal([]byte(data), &policy); err != nil {
+		return nil, fmt.Errorf("invalid policy of %s bucket: %w", r.bucket, err)
+	}
+
+	return policy, nil
+}
+
+// checkVisibility determines if the visibility of the file can be set without changing the bucket policy, a file
+// under a public directory can't be made private, and it can be made public alone only by its object ACL.
+func (r *Minio) checkVisibility(file, visibility string) error {
+	policy, err := r.bucketPolicy()
+	if err != nil {
+		return err
+	}
+
+	public := isPublicResource(policy, visibilityResource(r.bucket, r.key(file)))
+	if visibility == VisibilityPrivate && public {
+		return fmt.Errorf("%w: %s", ErrVisibilityShadowed, file)
+	}
+	if visibility == VisibilityPublic && !public && !r.acl {
+		return fmt.Errorf("%s can't be made public alone, make its directory public or enable the acl of %s disk", file, r.disk)
+	}
+
+	return nil
+}
+
+// putVisibility checks the visibility of the options before the file is written, so the file isn't written if its
+// visibility can't be set, and converts it to the ACL of the options if the acl of the disk is enabled.
+func (r *Minio) putVisibility(file string, opts *putOptions) error {
+	if opts.visibility == "" {
+		return nil
+	}
+	if err := validVisibility(opts.visibility); err != nil {
+		return err
+	}
+	if err := r.checkVisibility(file, opts.visibility); err != nil {
+		return err
+	}
+	if r.acl {
+		opts.acl = visibilityACL(opts.visibility)
+	}
+
+	return nil
+}
+
+// isPublicGrant determines if the object ACL grants anonymous read access.
+func isPublicGrant(grants []minio.Grant) bool {
+	for _, grant := range grants {
+		if grant.Grantee.URI == allUsersURI && (grant.Permission == "READ" || grant.Permission == "FULL_CONTROL") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPublicResource determines if the policy allows anything to read the resource without conditions.
+func isPublicResource(policy map[string]any, resource string) bool {
+	public := false
+	for _, item := range policyStatements(policy) {
+		statement, ok := item.(map[string]any)
+		if !ok || statement["Condition"] != nil || !isPublicPrincipal(statement["Principal"]) {
+			continue
+		}
+		var actions []string
+		for _, action := range policyStrings(statement["Action"]) {
+			actions = append(actions, strings.ToLower(action))
+		}
+		if !wildcardMatchAny(actions, "s3:getobject") || !wildcardMatchAny(policyStrings(statement["Resource"]), resource) {
+			continue
+		}
+
+		switch statement["Effect"] {
+		case "Deny":
+			return false
+		case "Allow":
+			public = true
+		}
+	}
+
+	return public
+}
+
+func isPublicPrincipal(principal any) bool {
+	if value, ok := principal.(string); ok {
+		return value == "*"
+	}
+	if value, ok := principal.(map[string]any); ok {
+		for _, item := range policyStrings(value["AWS"]) {
+			if item == "*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func policyStatements(policy map[string]any) []any {
+	switch statements := policy["Statement"].(type) {
+	case []any:
+		return statements
+	case map[string]any:
+		return []any{statements}
+	default:
+		return nil
+	}
+}
+
+// policyStrings converts a policy field that can be either a string or a list of strings.
+func policyStrings(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []any:
+		var values []string
+		for _, item := range value {
+			if item, ok := item.(string); ok {
+				values = append(values, item)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+func validVisibility(visibility string) error {
+	if visibility != VisibilityPublic && visibility != VisibilityPrivate {
+		return fmt.Errorf("invalid visibility %s, it should be %s or %s", visibility, VisibilityPublic, VisibilityPrivate)
+	}
+
+	return nil
+}
+
+// visibilityACL converts the visibility to the canned ACL of S3.
+func visibilityACL(visibility string) string {
+	if visibility == VisibilityPublic {
+		return "public-read"
+	}
+
+	return "private"
+}
+
+func visibilityResource(bucket, file string) string {
+	file = strings.TrimPrefix(file, "/")
+	if file == "" || strings.HasSuffix(file, "/") {
+		file += "*"
+	}
+
+	return "arn:aws:s3:::" + bucket + "/" + file
+}
+
+// wildcardMatchAny determines if the value matches any of the patterns, the patterns can contain
+// the * and ? wildcards of the policy language.
+func wildcardMatchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if wildcardMatch(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func wildcardMatch(pattern, value string) bool {
+	for pattern != "" {
+		switch pattern[0] {
+		case '*':
+			for i := len(value); i >= 0; i-- {
+				if wildcardMatch(pattern[1:], value[i:]) {
+					return true
+				}
+			}
+
+			return false
+		case '?':
+			if value == "" {
+				return false
+			}
+		default:
+			if value == "" || pattern[0] != value[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		value = value[1:]
+	}
+
+	return value == ""
+}
@@ -0,0 +1,76 @@
+package minio
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicGrant(t *testing.T) {
+	owner := minio.Grant{Grantee: minio.Grantee{ID: "owner"}, Permission: "FULL_CONTROL"}
+
+	assert.False(t, isPublicGrant(nil))
+	assert.False(t, isPublicGrant([]minio.Grant{owner}))
+	assert.False(t, isPublicGrant([]minio.Grant{owner, {Grantee: minio.Grantee{URI: allUsersURI}, Permission: "WRITE"}}))
+	assert.True(t, isPublicGrant([]minio.Grant{owner, {Grantee: minio.Grantee{URI: allUsersURI}, Permission: "READ"}}))
+}
+
+func TestIsPublicResource(t *testing.T) {
+	var policy map[string]any
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Principal": "*",
+				"Action": "s3:GetObject",
+				"Resource": "arn:aws:s3:::goravel/public/*"
+			},
+			{
+				"Effect": "Deny",
+				"Principal": {"AWS": ["*"]},
+				"Action": ["s3:*"],
+				"Resource": ["arn:aws:s3:::goravel/public/secret.txt"]
+			},
+			{
+				"Effect": "Allow",
+				"Principal": "*",
+				"Action": "s3:GetObject",
+				"Resource": "arn:aws:s3:::goravel/conditional/*",
+				"Condition": {"IpAddress": {"aws:SourceIp": "127.0.0.1/32"}}
+			}
+		]
+	}`), &policy))
+
+	assert.True(t, isPublicResource(policy, visibilityResource("goravel", "public/1.txt")))
+	assert.True(t, isPublicResource(policy, visibilityResource("goravel", "/public/a/1.txt")))
+	assert.False(t, isPublicResource(policy, visibilityResource("goravel", "public/secret.txt")))
+	assert.False(t, isPublicResource(policy, visibilityResource("goravel", "Public/1.txt")))
+	assert.False(t, isPublicResource(policy, visibilityResource("goravel", "conditional/1.txt")))
+	assert.False(t, isPublicResource(policy, visibilityResource("goravel", "private/1.txt")))
+	assert.False(t, isPublicResource(map[string]any{}, visibilityResource("goravel", "public/1.txt")))
+}
+
+func TestVisibilityACL(t *testing.T) {
+	assert.Equal(t, "public-read", visibilityACL(VisibilityPublic))
+	assert.Equal(t, "private", visibilityACL(VisibilityPrivate))
+}
+
+func TestVisibilityResource(t *testing.T) {
+	assert.Equal(t, "arn:aws:s3:::goravel/a/1.txt", visibilityResource("goravel", "a/1.txt"))
+	assert.Equal(t, "arn:aws:s3:::goravel/a/1.txt", visibilityResource("goravel", "/a/1.txt"))
+	assert.Equal(t, "arn:aws:s3:::goravel/a/*", visibilityResource("goravel", "a/"))
+	assert.Equal(t, "arn:aws:s3:::goravel/*", visibilityResource("goravel", ""))
+}
+
+func TestWildcardMatch(t *testing.T) {
+	assert.True(t, wildcardMatch("*", ""))
+	assert.True(t, wildcardMatch("a/*", "a/b/c.txt"))
+	assert.True(t, wildcardMatch("a/?.txt", "a/b.txt"))
+	assert.True(t, wildcardMatch("a/*/c.txt", "a/b/c.txt"))
+	assert.False(t, wildcardMatch("a/?.txt", "a/bb.txt"))
+	assert.False(t, wildcardMatch("a/*", "b/c.txt"))
+	assert.False(t, wildcardMatch("a", "ab"))
+}